@@ -8,13 +8,17 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
+	"github.com/infravillage/tagtastic/internal/changelog"
 	"github.com/infravillage/tagtastic/internal/cli"
+	"github.com/infravillage/tagtastic/internal/clierror"
 	"github.com/infravillage/tagtastic/internal/config"
 	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/git"
+	"github.com/infravillage/tagtastic/internal/history"
 	"github.com/infravillage/tagtastic/internal/output"
 	"golang.org/x/mod/semver"
 	"golang.org/x/term"
@@ -27,11 +31,13 @@ var (
 )
 
 func main() {
+	started := time.Now()
 	repo, err := data.NewEmbeddedThemeRepository()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to load themes: %v\n", err)
 		os.Exit(1)
 	}
+	loadDuration := time.Since(started)
 
 	deps := cli.Dependencies{
 		Themes:           repo,
@@ -75,6 +81,7 @@ func main() {
 	if err != nil {
 		reportParseError(err, wantsJSONErrors(args))
 	}
+	app.Logger().Debug("loaded embedded themes", "themes", len(repo.GetAllThemeNames()), "duration", loadDuration)
 
 	if err := ctx.Run(); err != nil {
 		reportRunError(err, wantsJSONErrors(args))
@@ -145,8 +152,8 @@ func latestCodename() string {
 	}
 
 	cfg, err := config.Load("")
-	if err == nil && len(cfg.UsedCodenames) > 0 {
-		if codename := latestCodenameFromConfig(cfg.UsedCodenames); codename != "" {
+	if err == nil {
+		if codename := latestCodenameFromConfig(cfg); codename != "" {
 			return codename
 		}
 	}
@@ -155,20 +162,9 @@ func latestCodename() string {
 	if err != nil {
 		return ""
 	}
-
-	for _, line := range strings.Split(string(payload), "\n") {
-		line = strings.TrimSpace(line)
-		if !strings.HasPrefix(line, "## [") {
-			continue
-		}
-		if strings.Contains(line, "[Unreleased]") {
-			continue
-		}
-		if idx := strings.Index(line, "– \""); idx != -1 {
-			fragment := line[idx+len("– \""):]
-			if end := strings.Index(fragment, "\""); end != -1 {
-				return fragment[:end]
-			}
+	for _, release := range changelog.ParseReleases(string(payload)) {
+		if release.Codename != "" {
+			return release.Codename
 		}
 	}
 
@@ -182,21 +178,12 @@ func defaultValue(value, fallback string) string {
 	return value
 }
 
-func latestCodenameFromConfig(values map[string]string) string {
-	latest := ""
-	latestKey := ""
-	for key, value := range values {
-		clean := normalizeSemver(key)
-		if clean == "" {
-			continue
-		}
-		if latestKey == "" || semver.Compare(clean, latestKey) > 0 {
-			latestKey = clean
-			latest = value
-		}
+func latestCodenameFromConfig(cfg config.Config) string {
+	entries := history.FromConfig(cfg)
+	if len(entries) == 0 {
+		return ""
 	}
-
-	return strings.TrimSpace(latest)
+	return strings.TrimSpace(entries[len(entries)-1].Codename)
 }
 
 func latestCodenameFromTags() string {
@@ -204,48 +191,22 @@ func latestCodenameFromTags() string {
 		return ""
 	}
 
-	output, err := exec.Command("git", "tag", "-l", "v*").Output()
+	tags, err := git.Tags(".")
 	if err != nil {
 		return ""
 	}
 
-	latest := ""
-	for _, raw := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		tag := strings.TrimSpace(raw)
-		if tag == "" {
-			continue
-		}
-		normalized := normalizeSemver(tag)
+	latest, subject := "", ""
+	for _, tag := range tags {
+		normalized := normalizeSemver(tag.Name)
 		if normalized == "" {
 			continue
 		}
 		if latest == "" || semver.Compare(normalized, latest) > 0 {
-			latest = normalized
+			latest, subject = normalized, tag.Subject
 		}
 	}
-
-	if latest == "" {
-		return ""
-	}
-
-	message, err := exec.Command("git", "tag", "-l", latest, "--format=%(contents:subject)").Output()
-	if err != nil {
-		return ""
-	}
-	return extractCodename(strings.TrimSpace(string(message)))
-}
-
-func extractCodename(message string) string {
-	if message == "" {
-		return ""
-	}
-	if idx := strings.Index(message, "– "); idx != -1 {
-		return strings.TrimSpace(message[idx+len("– "):])
-	}
-	if idx := strings.Index(message, "- "); idx != -1 {
-		return strings.TrimSpace(message[idx+len("- "):])
-	}
-	return ""
+	return git.TagCodename(subject)
 }
 
 func normalizeSemver(value string) string {
@@ -284,20 +245,9 @@ func resolveVersion(value string) string {
 }
 
 func reportParseError(err error, jsonErrors bool) {
-	if jsonErrors {
-		fmt.Fprintf(os.Stderr, "{\"error\":%q,\"type\":\"parse\",\"code\":2}\n", err.Error())
-		os.Exit(2)
-	}
-	fmt.Fprintf(os.Stderr, "error: %v\n", err)
-	fmt.Fprintln(os.Stderr, "Run \"tagtastic --help\" for usage.")
-	os.Exit(2)
+	os.Exit(clierror.Report(os.Stderr, clierror.New(clierror.CodeUsage, err, "run `tagtastic --help` for usage"), jsonErrors))
 }
 
 func reportRunError(err error, jsonErrors bool) {
-	if jsonErrors {
-		fmt.Fprintf(os.Stderr, "{\"error\":%q,\"type\":\"runtime\",\"code\":1}\n", err.Error())
-		os.Exit(1)
-	}
-	fmt.Fprintf(os.Stderr, "error: %v\n", err)
-	os.Exit(1)
+	os.Exit(clierror.Report(os.Stderr, err, jsonErrors))
 }
@@ -6,12 +6,13 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/infravillage/tagtastic/internal/changelog"
 )
 
 type colorEntry struct {
@@ -81,36 +82,14 @@ func loadColors(path string) ([]string, error) {
 }
 
 func loadUsedCodenames(path string) (map[string]struct{}, error) {
-	file, err := os.Open(path)
+	payload, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return map[string]struct{}{}, nil
 		}
 		return nil, err
 	}
-	defer func() { _ = file.Close() }()
-
-	used := make(map[string]struct{})
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if idx := strings.Index(line, "– \""); idx != -1 {
-			fragment := line[idx+len("– \""):]
-			if end := strings.Index(fragment, "\""); end != -1 {
-				codename := fragment[:end]
-				codename = strings.TrimSpace(codename)
-				if codename != "" {
-					used[codename] = struct{}{}
-				}
-			}
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	return used, nil
+	return changelog.Codenames(string(payload)), nil
 }
 
 func fatal(err error) {
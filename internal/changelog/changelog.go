@@ -0,0 +1,58 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+// Package changelog reads release headers from a Keep a Changelog file.
+package changelog
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Release is a versioned section header such as
+//
+//	## [0.2.0-beta.1] – "Asparagus" – 2026-01-04
+type Release struct {
+	Version  string
+	Codename string
+	Date     string
+}
+
+// headerPattern accepts an en dash or a hyphen as separator.
+var headerPattern = regexp.MustCompile(`^##\s+\[([^\]]+)\]\s*(?:[–-]\s*"([^"]*)"\s*)?(?:[–-]\s*(\S+))?`)
+
+// ParseReleases returns the release headers in file order (newest first in
+// a conventional changelog). The Unreleased section is skipped, and a
+// leading "v" is removed from versions.
+func ParseReleases(content string) []Release {
+	var releases []Release
+	for _, line := range strings.Split(content, "\n") {
+		match := headerPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		version := strings.TrimPrefix(strings.TrimSpace(match[1]), "v")
+		if strings.EqualFold(version, "unreleased") {
+			continue
+		}
+		releases = append(releases, Release{
+			Version:  version,
+			Codename: strings.TrimSpace(match[2]),
+			Date:     strings.TrimSpace(match[3]),
+		})
+	}
+	return releases
+}
+
+// Codenames returns the set of codenames used by released sections.
+func Codenames(content string) map[string]struct{} {
+	used := map[string]struct{}{}
+	for _, release := range ParseReleases(content) {
+		if release.Codename != "" {
+			used[release.Codename] = struct{}{}
+		}
+	}
+	return used
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package changelog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReleases(t *testing.T) {
+	content := `# Changelog
+
+## [Unreleased]
+
+### Added
+- N/A
+
+## [0.2.0-beta.1] – "Asparagus" – 2026-01-04
+
+### Added
+- Something
+
+## [v0.1.1] - "Aquamarine" - 2025-12-01
+## [0.1.0] – 2025-11-01
+`
+
+	want := []Release{
+		{Version: "0.2.0-beta.1", Codename: "Asparagus", Date: "2026-01-04"},
+		{Version: "0.1.1", Codename: "Aquamarine", Date: "2025-12-01"},
+		{Version: "0.1.0", Date: "2025-11-01"},
+	}
+	if got := ParseReleases(content); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected releases: %+v", got)
+	}
+
+	used := Codenames(content)
+	if len(used) != 2 {
+		t.Fatalf("expected two codenames, got %v", used)
+	}
+	if _, ok := used["Asparagus"]; !ok {
+		t.Fatalf("expected Asparagus in %v", used)
+	}
+}
@@ -6,25 +6,46 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"math/rand"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
+	"github.com/infravillage/tagtastic/internal/clierror"
 	"github.com/infravillage/tagtastic/internal/config"
 	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/git"
+	"github.com/infravillage/tagtastic/internal/history"
 	"github.com/infravillage/tagtastic/internal/output"
+	"github.com/infravillage/tagtastic/internal/policy"
+	"github.com/infravillage/tagtastic/internal/selection"
+	"github.com/infravillage/tagtastic/internal/slug"
+	"golang.org/x/term"
 )
 
 type Dependencies struct {
 	Themes             data.ThemeRepository
 	FormatterFactory   func(format string) (output.Formatter, error)
 	Out                io.Writer
+	Err                io.Writer
 	VersionInfo        VersionInfo
 	ConfigPathResolver func() string
+	// VerbosityResolver reports the level chosen by -q, -v and --debug.
+	VerbosityResolver func() Verbosity
+	// Logger receives diagnostics. NewCLI creates it once per run; its
+	// level follows -v and --debug after the arguments are parsed.
+	Logger *slog.Logger
+	// Now and GitInfo supply the audit details stored with recorded
+	// codenames.
+	Now     func() time.Time
+	GitInfo func(dir string) git.Info
 }
 
 type VersionInfo struct {
@@ -33,85 +54,226 @@ type VersionInfo struct {
 	Date    string
 }
 
+// withDefaults fills in the placeholders used by builds without linker flags.
+func (v VersionInfo) withDefaults() VersionInfo {
+	if v.Version == "" {
+		v.Version = "dev"
+	}
+	if v.Commit == "" {
+		v.Commit = "none"
+	}
+	if v.Date == "" {
+		v.Date = "unknown"
+	}
+	return v
+}
+
 type CLI struct {
 	Quiet      bool        `short:"q" long:"quiet" help:"Suppress non-essential output"`
+	Verbose    bool        `short:"v" long:"verbose" help:"Log diagnostics to stderr"`
+	Debug      bool        `long:"debug" help:"Log detailed diagnostics to stderr (implies --verbose)"`
 	JSONErrors bool        `long:"json-errors" help:"Emit errors as JSON"`
 	ConfigPath string      `long:"config-path" help:"Config file path override"`
 	Generate   GenerateCmd `cmd:"" help:"Generate a codename"`
 	List       ListCmd     `cmd:"" help:"List codenames in a theme"`
 	Themes     ThemesCmd   `cmd:"" help:"List available themes"`
 	Validate   ValidateCmd `cmd:"" help:"Validate a codename"`
+	History    HistoryCmd  `cmd:"" help:"List recorded release codenames"`
+	Promote    PromoteCmd  `cmd:"" help:"Move the unreleased codename onto a release version"`
+	Release    ReleaseCmd  `cmd:"" help:"Prepare a release: update CHANGELOG.md and VERSION, record the codename and tag"`
 	Config     ConfigCmd   `cmd:"" help:"Manage local config"`
 	Version    VersionCmd  `cmd:"" help:"Show version"`
+
+	// logger is the shared deps.Logger; AfterApply sets logLevel from the
+	// parsed flags.
+	logger   *slog.Logger
+	logLevel *slog.LevelVar
 }
 
 func NewCLI(deps Dependencies) *CLI {
 	if deps.Out == nil {
 		deps.Out = os.Stdout
 	}
+	if deps.Err == nil {
+		deps.Err = os.Stderr
+	}
 	if deps.FormatterFactory == nil {
 		deps.FormatterFactory = output.NewFormatter
 	}
-
-	app := &CLI{
-		Generate: GenerateCmd{deps: deps},
-		List:     ListCmd{deps: deps},
-		Themes:   ThemesCmd{deps: deps},
-		Validate: ValidateCmd{deps: deps},
-		Config:   ConfigCmd{deps: deps},
-		Version:  VersionCmd{deps: deps},
+	if deps.Now == nil {
+		deps.Now = time.Now
+	}
+	if deps.GitInfo == nil {
+		deps.GitInfo = git.Describe
 	}
 
-	app.Generate.deps.ConfigPathResolver = func() string { return app.ConfigPath }
-	app.List.deps.ConfigPathResolver = func() string { return app.ConfigPath }
-	app.Themes.deps.ConfigPathResolver = func() string { return app.ConfigPath }
-	app.Validate.deps.ConfigPathResolver = func() string { return app.ConfigPath }
-	app.Config.Init.deps = deps
-	app.Config.Show.deps = deps
-	app.Config.Reset.deps = deps
-	app.Config.Init.deps.ConfigPathResolver = func() string { return app.ConfigPath }
-	app.Config.Show.deps.ConfigPathResolver = func() string { return app.ConfigPath }
-	app.Config.Reset.deps.ConfigPathResolver = func() string { return app.ConfigPath }
-	app.Config.deps.ConfigPathResolver = func() string { return app.ConfigPath }
+	// Global flags are read through resolvers since commands receive their
+	// dependencies before the arguments are parsed.
+	app := &CLI{}
+	deps.ConfigPathResolver = func() string { return app.ConfigPath }
+	deps.VerbosityResolver = app.verbosity
+	app.logLevel = new(slog.LevelVar)
+	app.logLevel.Set(logLevel(VerbosityNormal))
+	app.logger = slog.New(slog.NewTextHandler(deps.Err, &slog.HandlerOptions{Level: app.logLevel}))
+	deps.Logger = app.logger
+
+	app.Generate = GenerateCmd{deps: deps}
+	app.List = ListCmd{deps: deps}
+	app.Themes = ThemesCmd{deps: deps}
+	app.Validate = ValidateCmd{deps: deps}
+	app.History = HistoryCmd{List: HistoryListCmd{deps: deps}, Import: HistoryImportCmd{deps: deps}}
+	app.Promote = PromoteCmd{deps: deps}
+	app.Release = ReleaseCmd{deps: deps}
+	app.Version = VersionCmd{deps: deps}
+	app.Config = ConfigCmd{
+		Init:     ConfigInitCmd{deps: deps},
+		Show:     ConfigShowCmd{deps: deps},
+		Reset:    ConfigResetCmd{deps: deps},
+		Migrate:  ConfigMigrateCmd{deps: deps},
+		Get:      ConfigGetCmd{deps: deps},
+		Set:      ConfigSetCmd{deps: deps},
+		Unset:    ConfigUnsetCmd{deps: deps},
+		Validate: ConfigValidateCmd{deps: deps},
+		Path:     ConfigPathCmd{deps: deps},
+		deps:     deps,
+	}
 
 	return app
 }
 
 type GenerateCmd struct {
-	Theme   string   `short:"t" long:"theme" help:"Theme to use" default:"crayola_colors"`
-	Seed    int64    `short:"s" long:"seed" help:"Random seed (0 uses time)" default:"0"`
-	Exclude []string `short:"e" long:"exclude" help:"Comma-separated names to exclude" sep:","`
-	Format  string   `short:"f" long:"format" help:"Output format (text, json, shell)" default:"text"`
-	Record  bool     `long:"record" help:"Record the selected codename in config"`
-	deps    Dependencies
+	Theme         string   `short:"t" long:"theme" help:"Theme to use (defaults to config, then crayola_colors)"`
+	Seed          int64    `short:"s" long:"seed" help:"Random seed (0 uses time)" default:"0"`
+	Exclude       []string `short:"e" long:"exclude" help:"Comma-separated names to exclude" sep:","`
+	Format        string   `short:"f" long:"format" help:"Output format (text, json, yaml, ndjson, template, shell, github, gitlab-dotenv, azure, make, properties, tfvars, ldflags; defaults to config, then text)"`
+	Record        bool     `long:"record" help:"Record the selected codename in config"`
+	Note          string   `long:"note" help:"Note stored with the recorded codename (requires --record)"`
+	Version       string   `long:"version" help:"Record under this release version instead of unreleased (requires --record)"`
+	Explain       bool     `long:"explain" help:"Explain how the codename was chosen (stderr, or under \"explain\" in JSON)"`
+	Where         []string `short:"w" long:"where" help:"Only consider items whose attribute matches key=value or key~regex (repeatable)"`
+	Template      string   `long:"template" help:"Go template rendered with the result (implies --format template)"`
+	SlugStyle     string   `long:"slug-style" help:"Slug style (kebab, snake, camel, screaming-snake, dns1123, pep440; default kebab)"`
+	SlugMaxLength int      `long:"slug-max-length" help:"Shorten slugs to at most this many characters"`
+	deps          Dependencies
 }
 
 func (cmd GenerateCmd) Run() error {
+	if cmd.Note != "" && !cmd.Record {
+		return clierror.Usagef("--note requires --record")
+	}
+	if cmd.Version != "" {
+		if !cmd.Record {
+			return clierror.Usagef("--version requires --record")
+		}
+		key, err := config.VersionKey(cmd.Version)
+		if err != nil {
+			return clierror.New(clierror.CodeUsage, err, "")
+		}
+		cmd.Version = key
+	}
+	if cmd.Template != "" && cmd.Format == "" {
+		cmd.Format = "template"
+	}
+	where, err := whereFilter(cmd.Where)
+	if err != nil {
+		return err
+	}
+	slugs, err := slugOptions(cmd.SlugStyle, cmd.SlugMaxLength)
+	if err != nil {
+		return err
+	}
+
+	settings, err := resolveSettings(cmd.deps, "", map[string]string{
+		"default_theme":  cmd.Theme,
+		"default_format": cmd.Format,
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Theme = settings.Get("default_theme")
+	cmd.Format = settings.Get("default_format")
+
 	formatter, err := cmd.deps.FormatterFactory(cmd.Format)
 	if err != nil {
 		return err
 	}
+	if formatter, err = prepareFormatter(formatter, cmd.deps.Out, cmd.Template, slugs); err != nil {
+		return err
+	}
 
 	theme, err := cmd.deps.Themes.GetThemeByName(cmd.Theme)
 	if err != nil {
 		return err
 	}
+	cmd.deps.logger().Debug("selected theme", "theme", theme.ID, "items", len(theme.Items))
 
-	available := data.FilterItems(theme.Items, cmd.Exclude)
-	if len(available) == 0 {
-		return fmt.Errorf("no available codenames after exclusions")
+	path, err := resolveConfigPath(cmd.deps)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(cmd.deps, path)
+	if err != nil {
+		return err
+	}
+	// Refuse before anything is printed, so scripts never get a codename
+	// together with a conflict.
+	if cmd.Version != "" {
+		if err := cfg.EnsureUnrecorded(cmd.Version); err != nil {
+			return err
+		}
+	}
+	filters := []selection.Filter{selection.ExcludeNames(cmd.Exclude)}
+	if where != nil {
+		filters = append(filters, where)
+	}
+	if checker := policy.New(cfg); checker.Enabled() {
+		if violations := checker.CheckTheme(theme.ID, cmd.Version); len(violations) > 0 {
+			return policy.Error(violations)
+		}
+		filters = append(filters, checker.Filter(cmd.Version))
 	}
 
-	seed := cmd.Seed
+	seed, seedSource := cmd.Seed, selection.SeedFromFlag
 	if seed == 0 {
-		seed = time.Now().UnixNano()
+		seed, seedSource = cmd.deps.Now().UnixNano(), selection.SeedFromTime
 	}
 
-	// #nosec G404 - math/rand is sufficient for non-cryptographic codename selection
-	picker := rand.New(rand.NewSource(seed))
-	selected := available[picker.Intn(len(available))]
+	result, err := selection.Select(theme, selection.Options{
+		Seed:       seed,
+		SeedSource: seedSource,
+		Filters:    filters,
+		Logger:     cmd.deps.logger(),
+	})
+	if err != nil {
+		if cmd.Explain {
+			_, _ = fmt.Fprintln(cmd.deps.Err, output.FormatExplanation(result.Explanation))
+		}
+		return err
+	}
+	selected := result.Item
+
+	generated := output.NewResult(selected)
+	if generated.Slug, err = output.Slug(selected, slugs); err != nil {
+		return err
+	}
+	generated.Theme = theme.ID
+	generated.Seed = seed
+	generated.SeedSource = seedSource
+	generated.Version = cmd.Version
+	version := cmd.deps.VersionInfo.withDefaults()
+	generated.Generator.Version = version.Version
+	if version.Commit != "none" {
+		generated.Generator.Commit = version.Commit
+	}
+	if cmd.Explain {
+		if explainer, ok := formatter.(output.ExplainFormatter); ok && explainer.EmbedsExplanation() {
+			generated.Explain = &result.Explanation
+		} else {
+			_, _ = fmt.Fprintln(cmd.deps.Err, output.FormatExplanation(result.Explanation))
+		}
+	}
 
-	outputText, err := formatter.FormatName(selected)
+	outputText, err := formatter.FormatResult(generated)
 	if err != nil {
 		return err
 	}
@@ -119,7 +281,13 @@ func (cmd GenerateCmd) Run() error {
 	_, _ = fmt.Fprintln(cmd.deps.Out, outputText)
 
 	if cmd.Record {
-		if err := recordCodename(cmd, selected); err != nil {
+		record := config.Record{
+			Codename: selected.Name,
+			Theme:    theme.ID,
+			Seed:     seed,
+			Notes:    cmd.Note,
+		}
+		if err := recordCodename(cmd, path, cfg, settings, record); err != nil {
 			return err
 		}
 	}
@@ -128,23 +296,78 @@ func (cmd GenerateCmd) Run() error {
 }
 
 type ListCmd struct {
-	Theme  string `short:"t" long:"theme" help:"Theme to list" default:"crayola_colors"`
-	Format string `short:"f" long:"format" help:"Output format (text, json)" default:"text"`
-	deps   Dependencies
+	Theme         string   `short:"t" long:"theme" help:"Theme to list (defaults to config, then crayola_colors)"`
+	Format        string   `short:"f" long:"format" help:"Output format (text, table/wide, json, yaml, ndjson, template; defaults to config, then text)"`
+	Where         []string `short:"w" long:"where" help:"Only list items whose attribute matches key=value or key~regex (repeatable)"`
+	Template      string   `long:"template" help:"Go template rendered for each item (implies --format template)"`
+	SlugStyle     string   `long:"slug-style" help:"Slug style for the table and templates (kebab, snake, camel, screaming-snake, dns1123, pep440; default kebab)"`
+	SlugMaxLength int      `long:"slug-max-length" help:"Shorten slugs to at most this many characters"`
+	deps          Dependencies
 }
 
 func (cmd ListCmd) Run() error {
+	if cmd.Template != "" && cmd.Format == "" {
+		cmd.Format = "template"
+	}
+	where, err := whereFilter(cmd.Where)
+	if err != nil {
+		return err
+	}
+	slugs, err := slugOptions(cmd.SlugStyle, cmd.SlugMaxLength)
+	if err != nil {
+		return err
+	}
+
+	settings, err := resolveSettings(cmd.deps, "", map[string]string{
+		"default_theme":  cmd.Theme,
+		"default_format": cmd.Format,
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Theme = settings.Get("default_theme")
+	cmd.Format = settings.Get("default_format")
+
 	formatter, err := cmd.deps.FormatterFactory(cmd.Format)
 	if err != nil {
 		return err
 	}
+	if formatter, err = prepareFormatter(formatter, cmd.deps.Out, cmd.Template, slugs); err != nil {
+		return err
+	}
+	// Only the table and template formats list slugs; the others print the
+	// theme items as they are.
+	switch formatter.(type) {
+	case output.TableFormatter, output.TemplateFormatter:
+	default:
+		if cmd.SlugStyle != "" || cmd.SlugMaxLength > 0 {
+			return clierror.Usagef("--slug-style and --slug-max-length need --format table or template for list")
+		}
+	}
 
 	theme, err := cmd.deps.Themes.GetThemeByName(cmd.Theme)
 	if err != nil {
 		return err
 	}
 
-	outputText, err := formatter.FormatList(theme.Items)
+	if table, ok := formatter.(output.TableFormatter); ok {
+		if table.Versions, err = usedVersions(cmd.deps); err != nil {
+			return err
+		}
+		table.Width = terminalWidth(cmd.deps.Out)
+		formatter = table
+	}
+
+	items := theme.Items
+	if where != nil {
+		items = selection.Apply(items, where)
+	}
+
+	if streamer, ok := formatter.(output.StreamFormatter); ok {
+		return streamer.WriteList(cmd.deps.Out, items)
+	}
+
+	outputText, err := formatter.FormatList(items)
 	if err != nil {
 		return err
 	}
@@ -153,18 +376,131 @@ func (cmd ListCmd) Run() error {
 	return nil
 }
 
+// usedVersions maps each recorded codename, normalized, to the versions it
+// was released under.
+func usedVersions(deps Dependencies) (map[string][]string, error) {
+	path, err := resolveConfigPath(deps)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := loadConfig(deps, path)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string][]string{}
+	for _, entry := range history.FromConfig(cfg) {
+		name := data.NormalizeName(entry.Codename)
+		versions[name] = append(versions[name], entry.Version)
+	}
+	return versions, nil
+}
+
+// terminalWidth returns the width of out when it is a terminal. Piped output
+// uses $COLUMNS, falling back to 80 columns.
+func terminalWidth(out io.Writer) int {
+	if isTerminal(out) {
+		if width, _, err := term.GetSize(int(out.(*os.File).Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
+
+func isTerminal(out io.Writer) bool {
+	file, ok := out.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// colorMode returns the color depth for swatches written to out. Only
+// terminals get color.
+func colorMode(out io.Writer) output.ColorMode {
+	if !isTerminal(out) {
+		return output.ColorNone
+	}
+	return output.DetectColorMode(os.Getenv)
+}
+
+// prepareFormatter enables swatches on the text and table formatters when out
+// is a terminal, sets the slug style on formatters that derive slugs from
+// listed items and hands the --template text to the template formatter.
+func prepareFormatter(formatter output.Formatter, out io.Writer, tmpl string, slugs slug.Options) (output.Formatter, error) {
+	switch f := formatter.(type) {
+	case output.TextFormatter:
+		f.Colors = colorMode(out)
+		formatter = f
+	case output.TableFormatter:
+		f.Colors = colorMode(out)
+		f.Slugs = slugs
+		formatter = f
+	case output.TemplateFormatter:
+		if tmpl == "" {
+			return nil, output.ErrTemplateRequired
+		}
+		f.Template = tmpl
+		f.Slugs = slugs
+		return f, nil
+	}
+	if tmpl != "" {
+		return nil, clierror.Usagef("--template requires --format template")
+	}
+	return formatter, nil
+}
+
+// slugOptions validates --slug-style and --slug-max-length.
+func slugOptions(style string, maxLength int) (slug.Options, error) {
+	parsed, err := slug.ParseStyle(style)
+	if err != nil {
+		return slug.Options{}, clierror.New(clierror.CodeUsage, err, "")
+	}
+	if maxLength < 0 {
+		return slug.Options{}, clierror.Usagef("--slug-max-length must not be negative")
+	}
+	return slug.Options{Style: parsed, MaxLength: maxLength}, nil
+}
+
+// whereFilter parses --where queries into a selection filter, or returns nil
+// when there are none.
+func whereFilter(exprs []string) (selection.Filter, error) {
+	if len(exprs) == 0 {
+		return nil, nil
+	}
+	conditions := make([]selection.Condition, 0, len(exprs))
+	for _, expr := range exprs {
+		cond, err := selection.ParseCondition(expr)
+		if err != nil {
+			return nil, clierror.New(clierror.CodeUsage, err, "")
+		}
+		conditions = append(conditions, cond)
+	}
+	return selection.Where(conditions), nil
+}
+
 type ThemesCmd struct {
-	Format string `short:"f" long:"format" help:"Output format (text, json)" default:"text"`
+	Format string `short:"f" long:"format" help:"Output format (text, json, yaml, ndjson; defaults to config, then text)"`
 	deps   Dependencies
 }
 
 func (cmd ThemesCmd) Run() error {
+	settings, err := resolveSettings(cmd.deps, "", map[string]string{"default_format": cmd.Format})
+	if err != nil {
+		return err
+	}
+	cmd.Format = settings.Get("default_format")
+
 	formatter, err := cmd.deps.FormatterFactory(cmd.Format)
 	if err != nil {
 		return err
 	}
 
 	names := cmd.deps.Themes.GetAllThemeNames()
+	if streamer, ok := formatter.(output.StreamFormatter); ok {
+		return streamer.WriteThemes(cmd.deps.Out, names)
+	}
+
 	outputText, err := formatter.FormatThemes(names)
 	if err != nil {
 		return err
@@ -175,15 +511,27 @@ func (cmd ThemesCmd) Run() error {
 }
 
 type ValidateCmd struct {
-	Name  string `arg:"" help:"Name to validate"`
-	Theme string `short:"t" long:"theme" help:"Theme to search"`
-	deps  Dependencies
+	Name    string `arg:"" help:"Name to validate"`
+	Theme   string `short:"t" long:"theme" help:"Theme to search"`
+	Policy  bool   `long:"policy" help:"Also check the name against the naming policy in config"`
+	Version string `long:"version" help:"Release version the name is proposed for (with --policy)"`
+	deps    Dependencies
 }
 
 func (cmd ValidateCmd) Run() error {
 	if cmd.Name == "" {
 		return fmt.Errorf("name is required")
 	}
+	if cmd.Version != "" {
+		if !cmd.Policy {
+			return clierror.Usagef("--version requires --policy")
+		}
+		key, err := config.VersionKey(cmd.Version)
+		if err != nil {
+			return clierror.New(clierror.CodeUsage, err, "")
+		}
+		cmd.Version = key
+	}
 
 	if cmd.Theme == "" {
 		names := cmd.deps.Themes.GetAllThemeNames()
@@ -193,8 +541,8 @@ func (cmd ValidateCmd) Run() error {
 				continue
 			}
 			if containsName(theme.Items, cmd.Name) {
-				_, _ = fmt.Fprintf(cmd.deps.Out, "Found in theme '%s'\n", themeName)
-				return nil
+				infof(cmd.deps, "Found in theme '%s'", themeName)
+				return cmd.checkPolicy(theme.ID)
 			}
 		}
 		return fmt.Errorf("name '%s' not found", cmd.Name)
@@ -206,17 +554,218 @@ func (cmd ValidateCmd) Run() error {
 	}
 
 	if containsName(theme.Items, cmd.Name) {
-		_, _ = fmt.Fprintf(cmd.deps.Out, "Found in theme '%s'\n", cmd.Theme)
-		return nil
+		infof(cmd.deps, "Found in theme '%s'", cmd.Theme)
+		return cmd.checkPolicy(theme.ID)
 	}
 	return fmt.Errorf("name '%s' not found in theme '%s'", cmd.Name, cmd.Theme)
 }
 
+// checkPolicy prints each naming rule the name breaks when --policy is set.
+func (cmd ValidateCmd) checkPolicy(themeID string) error {
+	if !cmd.Policy {
+		return nil
+	}
+
+	path, err := resolveConfigPath(cmd.deps)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(cmd.deps, path)
+	if err != nil {
+		return err
+	}
+
+	checker := policy.New(cfg)
+	if !checker.Enabled() {
+		infof(cmd.deps, "No naming policy configured in %s", path)
+		return nil
+	}
+	violations := checker.Check(policy.Proposal{Name: cmd.Name, Theme: themeID, Version: cmd.Version})
+	if len(violations) == 0 {
+		infof(cmd.deps, "Passes naming policy")
+		return nil
+	}
+	for _, violation := range violations {
+		_, _ = fmt.Fprintf(cmd.deps.Out, "policy %s\n", violation)
+	}
+	return fmt.Errorf("name '%s' breaks %d naming policy rule(s)", cmd.Name, len(violations))
+}
+
+type HistoryCmd struct {
+	List   HistoryListCmd   `cmd:"" default:"withargs" help:"List recorded releases (default)"`
+	Import HistoryImportCmd `cmd:"" help:"Backfill history from git tags and CHANGELOG.md"`
+}
+
+type HistoryListCmd struct {
+	Since      string `long:"since" help:"Only versions at or after this version"`
+	Limit      int    `short:"n" long:"limit" help:"Only the most recent N releases (0 for all)"`
+	Prerelease *bool  `long:"prerelease" negatable:"" help:"Only prereleases (--no-prerelease: only stable releases)"`
+	Format     string `short:"f" long:"format" help:"Output format (text, json, csv, markdown)" default:"text" enum:"text,json,csv,markdown"`
+	Path       string `short:"p" long:"path" help:"Config file path"`
+	deps       Dependencies
+}
+
+func (cmd HistoryListCmd) Run() error {
+	path, err := configCommandPath(cmd.deps, cmd.Path)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(cmd.deps, path)
+	if err != nil {
+		return err
+	}
+
+	entries, err := history.Filter(history.FromConfig(cfg), history.Options{
+		Since:      cmd.Since,
+		Limit:      cmd.Limit,
+		Prerelease: cmd.Prerelease,
+	})
+	if err != nil {
+		return err
+	}
+
+	rendered, err := history.Format(entries, cmd.Format)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(cmd.deps.Out, rendered)
+	return nil
+}
+
+type HistoryImportCmd struct {
+	From      []string `long:"from" help:"Sources to import (tags, changelog)" default:"tags,changelog" sep:","`
+	Changelog string   `long:"changelog" help:"Changelog path (defaults to CHANGELOG.md at the repository root)"`
+	Path      string   `short:"p" long:"path" help:"Config file path"`
+	DryRun    bool     `long:"dry-run" help:"Show what would be imported without writing"`
+	deps      Dependencies
+}
+
+func (cmd HistoryImportCmd) Run() error {
+	path, err := configCommandPath(cmd.deps, cmd.Path)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(cmd.deps, path)
+	if err != nil {
+		return err
+	}
+
+	root := filepath.Dir(path)
+	if discovery, err := config.Discover(root); err == nil && discovery.Root != "" {
+		root = discovery.Root
+	}
+
+	var candidates []history.Candidate
+	for _, source := range cmd.From {
+		switch strings.TrimSpace(source) {
+		case history.SourceTags:
+			tags, err := git.Tags(root)
+			if err != nil {
+				return err
+			}
+			candidates = append(candidates, history.TagCandidates(tags)...)
+		case history.SourceChangelog:
+			changelogPath := cmd.Changelog
+			if changelogPath == "" {
+				changelogPath = filepath.Join(root, "CHANGELOG.md")
+			}
+			payload, err := os.ReadFile(changelogPath)
+			if err != nil {
+				return fmt.Errorf("read changelog: %w", err)
+			}
+			candidates = append(candidates, history.ChangelogCandidates(string(payload))...)
+		default:
+			return fmt.Errorf("unknown import source %q (use %s)", source, strings.Join(history.ImportSources, ", "))
+		}
+	}
+
+	plan := history.PlanImport(cfg, candidates)
+	for _, entry := range plan.Added {
+		_, _ = fmt.Fprintf(cmd.deps.Out, "add\t%s\t%s\t%s\n", entry.Version, entry.Codename, entry.Notes)
+	}
+	for _, conflict := range plan.Conflicts {
+		_, _ = fmt.Fprintf(cmd.deps.Out, "conflict\t%s\n", conflict)
+	}
+
+	summary := fmt.Sprintf("%d to add, %d already recorded, %d conflicts", len(plan.Added), plan.Unchanged, len(plan.Conflicts))
+	if cmd.DryRun {
+		infof(cmd.deps, "Dry run: %s; no changes written to %s", summary, path)
+		return nil
+	}
+	if len(plan.Added) == 0 {
+		infof(cmd.deps, "Nothing to import into %s (%s)", path, summary)
+		return nil
+	}
+
+	doc, err := config.ReadDocument(path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range plan.Added {
+		if err := doc.SetRecord(entry.Version, entry.Record); err != nil {
+			return err
+		}
+	}
+	if err := writeConfigFile(path, doc.Bytes()); err != nil {
+		return err
+	}
+
+	infof(cmd.deps, "Imported into %s: %s", path, summary)
+	return nil
+}
+
+type PromoteCmd struct {
+	Version string `arg:"" help:"Release version to record the unreleased codename under"`
+	Path    string `short:"p" long:"path" help:"Config file path"`
+	DryRun  bool   `long:"dry-run" help:"Show the config diff without writing"`
+	deps    Dependencies
+}
+
+func (cmd PromoteCmd) Run() error {
+	version, err := config.VersionKey(cmd.Version)
+	if err != nil {
+		return clierror.New(clierror.CodeUsage, err, "")
+	}
+
+	var promoted config.Record
+	err = editConfig(cmd.deps, cmd.Path, cmd.DryRun, func(cfg *config.Config, doc *config.Document) error {
+		record, ok := cfg.UsedCodenames[config.UnreleasedKey]
+		if !ok || strings.TrimSpace(record.Codename) == "" {
+			return clierror.New(clierror.CodeRuntime, errors.New("no unreleased codename to promote"), "run `tagtastic generate --record` first")
+		}
+		if err := cfg.EnsureUnrecorded(version); err != nil {
+			return err
+		}
+		if err := doc.SetRecord(version, record); err != nil {
+			return err
+		}
+		if _, err := doc.Delete([]string{"used_codenames", config.UnreleasedKey}); err != nil {
+			return err
+		}
+		promoted = record
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !cmd.DryRun {
+		infof(cmd.deps, "Promoted %s to %s", promoted.Codename, version)
+	}
+	return nil
+}
+
 type ConfigCmd struct {
-	Init  ConfigInitCmd  `cmd:"" help:"Initialize local config"`
-	Show  ConfigShowCmd  `cmd:"" help:"Show local config"`
-	Reset ConfigResetCmd `cmd:"" help:"Remove local config"`
-	deps  Dependencies
+	Init     ConfigInitCmd     `cmd:"" help:"Initialize local config"`
+	Show     ConfigShowCmd     `cmd:"" help:"Show local config"`
+	Reset    ConfigResetCmd    `cmd:"" help:"Remove local config"`
+	Migrate  ConfigMigrateCmd  `cmd:"" help:"Rewrite config to the current schema"`
+	Get      ConfigGetCmd      `cmd:"" help:"Print a config value by dotted key"`
+	Set      ConfigSetCmd      `cmd:"" help:"Set a config value by dotted key"`
+	Unset    ConfigUnsetCmd    `cmd:"" help:"Remove a config value by dotted key"`
+	Validate ConfigValidateCmd `cmd:"" help:"Check config strictly and against available themes"`
+	Path     ConfigPathCmd     `cmd:"" help:"Show which config files are found and used"`
+	deps     Dependencies
 }
 
 type ConfigInitCmd struct {
@@ -247,7 +796,7 @@ func (cmd ConfigInitCmd) Run() error {
 	}
 
 	if cmd.DryRun {
-		_, _ = fmt.Fprintf(cmd.deps.Out, "Dry run: would initialize config at %s\n", path)
+		infof(cmd.deps, "Dry run: would initialize config at %s", path)
 		return nil
 	}
 
@@ -264,16 +813,22 @@ func (cmd ConfigInitCmd) Run() error {
 		return err
 	}
 
-	_, _ = fmt.Fprintf(cmd.deps.Out, "Initialized config at %s\n", path)
+	infof(cmd.deps, "Initialized config at %s", path)
 	return nil
 }
 
 type ConfigShowCmd struct {
-	Path string `short:"p" long:"path" help:"Config file path"`
-	deps Dependencies
+	Path      string `short:"p" long:"path" help:"Config file path"`
+	Effective bool   `long:"effective" help:"Show merged settings and the layer each came from"`
+	Format    string `short:"f" long:"format" help:"Output format (text shows the file as written, json the decoded config)" default:"text" enum:"text,json"`
+	deps      Dependencies
 }
 
 func (cmd ConfigShowCmd) Run() error {
+	if cmd.Effective {
+		return cmd.showEffective()
+	}
+
 	path := cmd.Path
 	if strings.TrimSpace(path) == "" {
 		resolved, err := resolveConfigPath(cmd.deps)
@@ -295,10 +850,59 @@ func (cmd ConfigShowCmd) Run() error {
 		return err
 	}
 
+	if cmd.Format == "json" {
+		cfg, warnings, err := config.Decode(payload)
+		if err != nil {
+			return err
+		}
+		printWarnings(cmd.deps, path, warnings)
+		return printJSON(cmd.deps, cfg)
+	}
+
 	_, _ = fmt.Fprint(cmd.deps.Out, string(payload))
 	return nil
 }
 
+func (cmd ConfigShowCmd) showEffective() error {
+	settings, err := resolveSettings(cmd.deps, cmd.Path, nil)
+	if err != nil {
+		return err
+	}
+	if cmd.Format == "json" {
+		return printJSON(cmd.deps, settings)
+	}
+
+	writer := tabwriter.NewWriter(cmd.deps.Out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "KEY\tVALUE\tSOURCE")
+	for _, setting := range settings.Settings {
+		source := setting.Source
+		switch {
+		case setting.Path != "":
+			source = fmt.Sprintf("%s (%s)", source, setting.Path)
+		case setting.Source == config.LayerEnv:
+			source = fmt.Sprintf("%s (%s)", source, config.EnvName(setting.Key))
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", setting.Key, defaultDisplay(setting.Value), source)
+	}
+	return writer.Flush()
+}
+
+func printJSON(deps Dependencies, value any) error {
+	encoded, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(deps.Out, string(encoded))
+	return nil
+}
+
+func defaultDisplay(value string) string {
+	if value == "" {
+		return `""`
+	}
+	return value
+}
+
 type ConfigResetCmd struct {
 	Path   string `short:"p" long:"path" help:"Config file path"`
 	DryRun bool   `long:"dry-run" help:"Preview changes without deleting"`
@@ -320,7 +924,7 @@ func (cmd ConfigResetCmd) Run() error {
 	}
 
 	if cmd.DryRun {
-		_, _ = fmt.Fprintf(cmd.deps.Out, "Dry run: would remove config at %s\n", path)
+		infof(cmd.deps, "Dry run: would remove config at %s", path)
 		return nil
 	}
 
@@ -328,26 +932,311 @@ func (cmd ConfigResetCmd) Run() error {
 		return err
 	}
 
-	_, _ = fmt.Fprintf(cmd.deps.Out, "Removed config at %s\n", path)
+	infof(cmd.deps, "Removed config at %s", path)
 	return nil
 }
 
-type VersionCmd struct {
+type ConfigMigrateCmd struct {
+	Path   string `short:"p" long:"path" help:"Config file path"`
+	DryRun bool   `long:"dry-run" help:"Show the changes without writing"`
+	deps   Dependencies
+}
+
+func (cmd ConfigMigrateCmd) Run() error {
+	path, err := configCommandPath(cmd.deps, cmd.Path)
+	if err != nil {
+		return err
+	}
+
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("config not found at %s (run: tagtastic config init)", path)
+		}
+		return err
+	}
+
+	migration, err := config.Migrate(payload)
+	if err != nil {
+		return err
+	}
+	printWarnings(cmd.deps, path, migration.Warnings)
+
+	if !migration.Changed {
+		infof(cmd.deps, "Config at %s already uses schema %d", path, migration.To)
+		return nil
+	}
+
+	if cmd.DryRun {
+		_, _ = fmt.Fprint(cmd.deps.Out, config.Diff(path, path+" (migrated)", payload, migration.Payload))
+		infof(cmd.deps, "Dry run: would migrate config at %s from schema %d to %d", path, migration.From, migration.To)
+		return nil
+	}
+
+	if err := os.WriteFile(path, migration.Payload, 0o600); err != nil {
+		return err
+	}
+	infof(cmd.deps, "Migrated config at %s from schema %d to %d", path, migration.From, migration.To)
+	return nil
+}
+
+type ConfigGetCmd struct {
+	Key  string `arg:"" help:"Dotted key (e.g. api.endpoint, used_codenames.0.1.0, used_codenames.0.1.0.notes)"`
+	Path string `short:"p" long:"path" help:"Config file path"`
 	deps Dependencies
 }
 
-func (cmd VersionCmd) Run() error {
-	version := cmd.deps.VersionInfo
-	if version.Version == "" {
-		version.Version = "dev"
+func (cmd ConfigGetCmd) Run() error {
+	path, err := configCommandPath(cmd.deps, cmd.Path)
+	if err != nil {
+		return err
 	}
-	if version.Commit == "" {
-		version.Commit = "none"
+
+	cfg, err := loadConfig(cmd.deps, path)
+	if err != nil {
+		return err
 	}
-	if version.Date == "" {
-		version.Date = "unknown"
+
+	value, err := config.GetKey(cfg, cmd.Key)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := config.FormatValue(value)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(cmd.deps.Out, rendered)
+	return nil
+}
+
+type ConfigSetCmd struct {
+	Key    string `arg:"" help:"Dotted key (e.g. default_theme, used_codenames.0.1.0, used_codenames.0.1.0.notes)"`
+	Value  string `arg:"" help:"Value to store; checked against the key's type"`
+	Path   string `short:"p" long:"path" help:"Config file path"`
+	DryRun bool   `long:"dry-run" help:"Show the diff without writing"`
+	deps   Dependencies
+}
+
+func (cmd ConfigSetCmd) Run() error {
+	return editConfig(cmd.deps, cmd.Path, cmd.DryRun, func(cfg *config.Config, doc *config.Document) error {
+		if err := config.SetKey(cfg, cmd.Key, cmd.Value); err != nil {
+			return clierror.Usagef("%w", err)
+		}
+		segments, err := config.KeySegments(cmd.Key)
+		if err != nil {
+			return err
+		}
+		if isRecordField(segments) {
+			return doc.SetRecord(segments[1], cfg.UsedCodenames[segments[1]])
+		}
+		value, err := config.GetKey(*cfg, cmd.Key)
+		if err != nil {
+			return err
+		}
+		return doc.Set(segments, value)
+	})
+}
+
+type ConfigUnsetCmd struct {
+	Key    string `arg:"" help:"Dotted key to remove"`
+	Path   string `short:"p" long:"path" help:"Config file path"`
+	DryRun bool   `long:"dry-run" help:"Show the diff without writing"`
+	deps   Dependencies
+}
+
+func (cmd ConfigUnsetCmd) Run() error {
+	return editConfig(cmd.deps, cmd.Path, cmd.DryRun, func(cfg *config.Config, doc *config.Document) error {
+		if err := config.UnsetKey(cfg, cmd.Key); err != nil {
+			return err
+		}
+		segments, err := config.KeySegments(cmd.Key)
+		if err != nil {
+			return err
+		}
+		if isRecordField(segments) {
+			return doc.SetRecord(segments[1], cfg.UsedCodenames[segments[1]])
+		}
+		_, err = doc.Delete(segments)
+		return err
+	})
+}
+
+// isRecordField reports whether key segments name a field of a
+// used_codenames record. Those edits rewrite the whole entry, so plain
+// entries become records and records with only a codename become plain.
+func isRecordField(segments []string) bool {
+	return len(segments) == 3 && segments[0] == "used_codenames"
+}
+
+type ConfigValidateCmd struct {
+	Path   string `short:"p" long:"path" help:"Config file path"`
+	Format string `short:"f" long:"format" help:"Findings format (text, json)" default:"text" enum:"text,json"`
+	deps   Dependencies
+}
+
+func (cmd ConfigValidateCmd) Run() error {
+	path, err := configCommandPath(cmd.deps, cmd.Path)
+	if err != nil {
+		return err
+	}
+
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("config not found at %s (run: tagtastic config init)", path)
+		}
+		return err
 	}
 
+	known := knownCodenames(cmd.deps.Themes)
+	findings := config.Validate(payload, config.ValidateOptions{
+		ThemeExists: func(name string) bool {
+			_, err := cmd.deps.Themes.GetThemeByName(name)
+			return err == nil
+		},
+		FormatKnown: func(name string) bool {
+			_, err := cmd.deps.FormatterFactory(name)
+			return err == nil
+		},
+		CodenameKnown: func(name string) bool {
+			_, ok := known[data.NormalizeName(name)]
+			return ok
+		},
+	})
+
+	if cmd.Format == "json" {
+		if err := printJSON(cmd.deps, findings); err != nil {
+			return err
+		}
+	} else {
+		for _, finding := range findings {
+			key := finding.Key
+			if key == "" {
+				key = "-"
+			}
+			_, _ = fmt.Fprintf(cmd.deps.Out, "%s\t%s\t%s\n", finding.Severity, key, finding.Message)
+		}
+	}
+
+	if config.HasErrors(findings) {
+		return clierror.New(clierror.CodeConfigInvalid, fmt.Errorf("config at %s is invalid", path), "fix the errors listed in the findings")
+	}
+	return nil
+}
+
+type ConfigPathCmd struct {
+	deps Dependencies
+}
+
+func (cmd ConfigPathCmd) Run() error {
+	sources, err := configSources(cmd.deps)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(cmd.deps.Out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "LAYER\tSTATUS\tORIGIN\tPATH")
+	for _, source := range sources {
+		status := "missing"
+		if info, err := os.Stat(source.Path); err == nil && !info.IsDir() {
+			status = "merged"
+		}
+		if source.Primary {
+			status += ", primary"
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", source.Layer, status, source.Origin, source.Path)
+	}
+	return writer.Flush()
+}
+
+// knownCodenames returns the normalized names and aliases of every item in
+// every theme.
+func knownCodenames(themes data.ThemeRepository) map[string]struct{} {
+	known := map[string]struct{}{}
+	for _, themeName := range themes.GetAllThemeNames() {
+		theme, err := themes.GetThemeByName(themeName)
+		if err != nil {
+			continue
+		}
+		for _, item := range theme.Items {
+			known[data.NormalizeName(item.Name)] = struct{}{}
+			for _, alias := range item.Aliases {
+				known[data.NormalizeName(alias)] = struct{}{}
+			}
+		}
+	}
+	return known
+}
+
+// editConfig applies edit to the config at path (or the resolved config
+// path) and writes the result, or prints the diff on dry run. edit receives
+// the decoded config for type checks and the document to change in place.
+func editConfig(deps Dependencies, path string, dryRun bool, edit func(cfg *config.Config, doc *config.Document) error) error {
+	path, err := configCommandPath(deps, path)
+	if err != nil {
+		return err
+	}
+
+	before, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	cfg, err := loadConfig(deps, path)
+	if err != nil {
+		return err
+	}
+	doc, err := config.ParseDocument(before)
+	if err != nil {
+		return err
+	}
+	if err := edit(&cfg, doc); err != nil {
+		return err
+	}
+	after := doc.Bytes()
+
+	diff := config.Diff(path, path, before, after)
+	if dryRun {
+		if diff == "" {
+			infof(deps, "Dry run: no changes to config at %s", path)
+			return nil
+		}
+		_, _ = fmt.Fprint(deps.Out, diff)
+		return nil
+	}
+	if diff == "" {
+		return nil
+	}
+
+	return writeConfigFile(path, after)
+}
+
+// writeConfigFile writes a config payload, creating its directory.
+func writeConfigFile(path string, payload []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, payload, 0o600)
+}
+
+func configCommandPath(deps Dependencies, path string) (string, error) {
+	if strings.TrimSpace(path) == "" {
+		resolved, err := resolveConfigPath(deps)
+		if err != nil {
+			return "", err
+		}
+		path = resolved
+	}
+	return config.ResolvePath(path)
+}
+
+type VersionCmd struct {
+	deps Dependencies
+}
+
+func (cmd VersionCmd) Run() error {
+	version := cmd.deps.VersionInfo.withDefaults()
 	_, _ = fmt.Fprintf(cmd.deps.Out, "tagtastic %s (commit %s, built %s)\n", version.Version, version.Commit, version.Date)
 	return nil
 }
@@ -372,50 +1261,184 @@ func containsName(items []data.CodeName, name string) bool {
 	return false
 }
 
-func recordCodename(cmd GenerateCmd, selected data.CodeName) error {
-	path, err := resolveConfigPath(cmd.deps)
-	if err != nil {
-		return err
+// recordCodename stores record under cmd.Version, or as the unreleased entry
+// when no version is given, adding when and by whom it was recorded. The
+// theme and format are written as the file's defaults only when it has none
+// and they came from a flag or the built-in defaults; values from the user
+// config or the environment are personal and stay out of the repository.
+func recordCodename(cmd GenerateCmd, path string, cfg config.Config, settings config.Effective, record config.Record) error {
+	key := config.UnreleasedKey
+	if cmd.Version != "" {
+		key = cmd.Version
 	}
 
-	cfg, err := config.Load(path)
+	doc, err := config.ReadDocument(path)
 	if err != nil {
 		return err
 	}
+	current := map[string]string{"default_theme": cfg.DefaultTheme, "default_format": cfg.DefaultFormat}
+	for _, key := range []string{"default_theme", "default_format"} {
+		setting, _ := settings.Lookup(key)
+		if current[key] != "" || (setting.Source != config.LayerFlag && setting.Source != config.LayerDefault) {
+			continue
+		}
+		if err := doc.Set([]string{key}, setting.Value); err != nil {
+			return err
+		}
+	}
+	info := cmd.deps.GitInfo(filepath.Dir(path))
+	record.RecordedAt = cmd.deps.Now().UTC().Truncate(time.Second)
+	record.Commit = info.Commit
+	record.RecordedBy = info.UserEmail
+	if err := doc.SetRecord(key, record); err != nil {
+		return err
+	}
+	return writeConfigFile(path, doc.Bytes())
+}
 
-	if cfg.UsedCodenames == nil {
-		cfg.UsedCodenames = map[string]string{}
+// resolveSettings merges built-in defaults, the user config, the repository
+// config, TAGTASTIC_* environment variables and flags, in that order. An
+// empty repoPath uses the regular config path resolution.
+func resolveSettings(deps Dependencies, repoPath string, flags map[string]string) (config.Effective, error) {
+	layers := []config.Layer{config.DefaultLayer()}
+
+	var sources []configSource
+	if strings.TrimSpace(repoPath) != "" {
+		resolved, err := config.ResolvePath(repoPath)
+		if err != nil {
+			return config.Effective{}, err
+		}
+		if userPath, err := config.DefaultPath(); err == nil && userPath != resolved {
+			sources = append(sources, configSource{Layer: config.LayerUser, Path: userPath})
+		}
+		sources = append(sources, configSource{Layer: config.LayerRepo, Path: resolved})
+	} else {
+		discovered, err := configSources(deps)
+		if err != nil {
+			return config.Effective{}, err
+		}
+		sources = discovered
 	}
 
-	cfg.DefaultTheme = cmd.Theme
-	cfg.DefaultFormat = cmd.Format
+	logger := deps.logger()
+	for _, source := range sources {
+		layer, err := config.FileLayer(source.Layer, source.Path)
+		if err != nil {
+			return config.Effective{}, err
+		}
+		logger.Debug("config layer", "layer", source.Layer, "path", source.Path)
+		layers = append(layers, layer)
+	}
 
-	cfg.UsedCodenames["unreleased"] = selected.Name
+	layers = append(layers, config.EnvLayer(os.LookupEnv), config.FlagLayer(flags))
+	return config.Merge(layers...), nil
+}
 
-	payload, err := config.Marshal(cfg)
-	if err != nil {
-		return err
+// configSource is a config file that takes part in settings resolution.
+type configSource struct {
+	Layer string
+	Path  string
+	// Origin describes how the path was chosen: "user", "discovered", the
+	// --config-path flag or the TAGTASTIC_CONFIG variable.
+	Origin string
+	// Primary marks the repository config that commands read history from
+	// and write to.
+	Primary bool
+}
+
+// configSources lists the user config followed by the repository configs in
+// increasing order of precedence. An explicit --config-path or
+// TAGTASTIC_CONFIG replaces discovery with that single file.
+func configSources(deps Dependencies) ([]configSource, error) {
+	var sources []configSource
+	userPath, err := config.DefaultPath()
+	if err == nil {
+		sources = append(sources, configSource{Layer: config.LayerUser, Path: userPath, Origin: "user"})
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
-		return err
+	if override, origin := configOverride(deps); override != "" {
+		path, err := config.ResolvePath(override)
+		if err != nil {
+			return nil, err
+		}
+		if path == userPath {
+			sources = sources[:0]
+		}
+		return append(sources, configSource{Layer: config.LayerRepo, Path: path, Origin: origin, Primary: true}), nil
 	}
 
-	if err := os.WriteFile(path, payload, 0o600); err != nil {
-		return err
+	discovery, err := discoverConfig()
+	if err != nil {
+		return nil, err
+	}
+	nearest := discovery.Nearest()
+	for i := len(discovery.Candidates) - 1; i >= 0; i-- {
+		candidate := discovery.Candidates[i]
+		if candidate == userPath {
+			continue
+		}
+		sources = append(sources, configSource{
+			Layer:   config.LayerRepo,
+			Path:    candidate,
+			Origin:  "discovered",
+			Primary: candidate == nearest,
+		})
 	}
+	return sources, nil
+}
 
-	return nil
+// loadConfig reads a config file and reports any warnings on stderr.
+func loadConfig(deps Dependencies, path string) (config.Config, error) {
+	cfg, warnings, err := config.LoadWithWarnings(path)
+	if err != nil {
+		return config.Config{}, err
+	}
+	deps.logger().Info("loaded config", "path", path, "used_codenames", len(cfg.UsedCodenames))
+	printWarnings(deps, path, warnings)
+	return cfg, nil
+}
+
+func printWarnings(deps Dependencies, path string, warnings []config.Warning) {
+	for _, warning := range warnings {
+		_, _ = fmt.Fprintf(deps.Err, "warning: %s: %s\n", path, warning)
+	}
 }
 
 func resolveConfigPath(deps Dependencies) (string, error) {
+	logger := deps.logger()
+	if override, origin := configOverride(deps); override != "" {
+		path, err := config.ResolvePath(override)
+		if err == nil {
+			logger.Debug("resolved config path", "path", path, "origin", origin)
+		}
+		return path, err
+	}
+	discovery, err := discoverConfig()
+	if err != nil {
+		return "", err
+	}
+	logger.Debug("resolved config path", "path", discovery.Nearest(), "origin", "discovery", "candidates", len(discovery.Candidates))
+	return discovery.Nearest(), nil
+}
+
+// configOverride returns an explicitly requested config path and where it
+// came from, or an empty path when discovery should be used.
+func configOverride(deps Dependencies) (string, string) {
 	if deps.ConfigPathResolver != nil {
 		if resolved := strings.TrimSpace(deps.ConfigPathResolver()); resolved != "" {
-			return config.ResolvePath(resolved)
+			return resolved, "--config-path"
 		}
 	}
-	if env := os.Getenv("TAGTASTIC_CONFIG"); strings.TrimSpace(env) != "" {
-		return config.ResolvePath(env)
+	if env := strings.TrimSpace(os.Getenv("TAGTASTIC_CONFIG")); env != "" {
+		return env, "TAGTASTIC_CONFIG"
+	}
+	return "", ""
+}
+
+func discoverConfig() (config.Discovery, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return config.Discovery{}, err
 	}
-	return config.ResolvePath(".tagtastic.yaml")
+	return config.Discover(wd)
 }
@@ -7,25 +7,53 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/kong"
+	"github.com/infravillage/tagtastic/internal/clierror"
+	"github.com/infravillage/tagtastic/internal/config"
 	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/git"
+	"github.com/infravillage/tagtastic/internal/output"
+	"github.com/infravillage/tagtastic/internal/selection"
 )
 
 func runCLI(t *testing.T, args ...string) (string, error) {
 	t.Helper()
 
+	stdout, _, err := runCLIStreams(t, args...)
+	return stdout, err
+}
+
+func runCLIStreams(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+
+	return runCLIWith(t, Dependencies{}, args...)
+}
+
+// runCLIWith runs the CLI with deps, filling in the embedded themes and
+// captured output streams.
+func runCLIWith(t *testing.T, deps Dependencies, args ...string) (string, string, error) {
+	t.Helper()
+
 	repo, err := data.NewEmbeddedThemeRepository()
 	if err != nil {
 		t.Fatalf("load themes: %v", err)
 	}
 
-	var out bytes.Buffer
-	cli := NewCLI(Dependencies{Themes: repo, Out: &out})
+	var out, errOut bytes.Buffer
+	deps.Themes, deps.Out, deps.Err = repo, &out, &errOut
+	cli := NewCLI(deps)
 	parser, err := kong.New(cli, kong.Name("tagtastic"))
 	if err != nil {
 		t.Fatalf("new parser: %v", err)
@@ -33,14 +61,14 @@ func runCLI(t *testing.T, args ...string) (string, error) {
 
 	ctx, err := parser.Parse(args)
 	if err != nil {
-		return out.String(), err
+		return out.String(), errOut.String(), err
 	}
 
 	if err := ctx.Run(); err != nil {
-		return out.String(), err
+		return out.String(), errOut.String(), err
 	}
 
-	return strings.TrimSpace(out.String()), nil
+	return strings.TrimSpace(out.String()), strings.TrimSpace(errOut.String()), nil
 }
 
 func TestGenerateCommand(t *testing.T) {
@@ -54,6 +82,26 @@ func TestGenerateCommand(t *testing.T) {
 	}
 }
 
+func TestGenerateCommand_SeedsFromClock(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	deps := Dependencies{Now: func() time.Time { return now }}
+	first, _, err := runCLIWith(t, deps, "generate", "--theme", "birds", "--format", "json")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	var result output.Result
+	if err := json.Unmarshal([]byte(first), &result); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, first)
+	}
+	if result.Seed != now.UnixNano() || result.SeedSource != selection.SeedFromTime {
+		t.Fatalf("expected the seed to come from the injected clock, got %d (%s)", result.Seed, result.SeedSource)
+	}
+	second, _, err := runCLIWith(t, deps, "generate", "--theme", "birds", "--format", "json")
+	if err != nil || second != first {
+		t.Fatalf("expected the same result for the same clock, got %q (%v)", second, err)
+	}
+}
+
 func TestListCommand(t *testing.T) {
 	output, err := runCLI(t, "list", "--theme", "birds")
 	if err != nil {
@@ -76,6 +124,132 @@ func TestThemesCommand(t *testing.T) {
 	}
 }
 
+func TestListAndThemes_StreamingFormats(t *testing.T) {
+	output, err := runCLI(t, "list", "--theme", "birds", "--format", "ndjson")
+	if err != nil {
+		t.Fatalf("list ndjson failed: %v", err)
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], `{"name":"Albatross"`) {
+		t.Fatalf("expected one JSON object per bird, got:\n%s", output)
+	}
+
+	output, err = runCLI(t, "themes", "--format", "yaml")
+	if err != nil {
+		t.Fatalf("themes yaml failed: %v", err)
+	}
+	if !strings.Contains(output, "- birds\n") {
+		t.Fatalf("expected YAML sequence, got:\n%s", output)
+	}
+}
+
+func TestListCommand_Table(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("used_codenames:\n  1.0.0: Blue Heron\n  1.1.0: Dove\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("COLUMNS", "60")
+
+	output, err := runCLI(t, "--config-path", configPath, "list", "--theme", "birds", "--format", "wide")
+	if err != nil {
+		t.Fatalf("list table failed: %v", err)
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) != 6 || !strings.HasPrefix(lines[0], "NAME") || !strings.Contains(lines[0], "USED IN") {
+		t.Fatalf("unexpected table:\n%s", output)
+	}
+	if !strings.Contains(lines[2], "blue-heron, heron  1.0.0") {
+		t.Fatalf("expected aliases and version for Blue Heron, got %q", lines[2])
+	}
+	for _, line := range lines {
+		if len([]rune(line)) > 60 {
+			t.Fatalf("line exceeds terminal width: %q", line)
+		}
+	}
+}
+
+func TestListCommand_NoSwatchesWhenPiped(t *testing.T) {
+	t.Setenv("COLORTERM", "truecolor")
+
+	output, err := runCLI(t, "list", "--theme", "crayola_colors")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if strings.Contains(output, "\x1b[") || !strings.HasPrefix(output, "Almond\n") {
+		t.Fatalf("expected plain names when not writing to a terminal, got:\n%.80q", output)
+	}
+}
+
+func TestWhereAndTemplate(t *testing.T) {
+	payload, err := runCLI(t, "list", "--theme", "cities", "--where", "continent=europe", "--where", "country~^[NP]", "--template", "{{.Name}}/{{.Attributes.country}}")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if payload != "Lisbon/Portugal\nOslo/Norway" {
+		t.Fatalf("unexpected list output:\n%s", payload)
+	}
+
+	payload, err = runCLI(t, "generate", "--theme", "landmarks", "--seed", "1", "--where", "continent=Oceania", "--format", "json")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	var result output.Result
+	if err := json.Unmarshal([]byte(payload), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if result.Name != "Uluru" || result.Attributes["country"] != "Australia" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if _, err := runCLI(t, "generate", "--theme", "birds", "--where", "country=Japan"); !errors.Is(err, selection.ErrPoolExhausted) {
+		t.Fatalf("expected an exhausted pool, got %v", err)
+	}
+	if _, err := runCLI(t, "list", "--where", "hex"); err == nil || !strings.Contains(err.Error(), "key=value or key~regex") {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+	if _, err := runCLI(t, "generate", "--format", "template"); !errors.Is(err, output.ErrTemplateRequired) {
+		t.Fatalf("expected a missing template error, got %v", err)
+	}
+}
+
+func TestSlugStyle(t *testing.T) {
+	payload, err := runCLI(t, "list", "--theme", "birds", "--template", "{{.Slug}}", "--slug-style", "camel")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(payload, "\nblueHeron\n") {
+		t.Fatalf("expected camelCase slugs:\n%s", payload)
+	}
+
+	payload, err = runCLI(t, "generate", "--theme", "birds", "--seed", "3", "--format", "shell", "--slug-style", "screaming-snake")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if payload != "RELEASE_CODENAME=DOVE" {
+		t.Fatalf("unexpected generate output: %q", payload)
+	}
+
+	payload, err = runCLI(t, "list", "--theme", "landmarks", "--format", "table", "--slug-style", "dns1123", "--slug-max-length", "8", "--where", "country=Tanzania")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(payload, "Kilimanjaro  kilimanj") {
+		t.Fatalf("expected shortened DNS-1123 slug:\n%s", payload)
+	}
+
+	if _, err := runCLI(t, "generate", "--slug-style", "title"); err == nil || !strings.Contains(err.Error(), "unknown slug style") {
+		t.Fatalf("expected unknown slug style error, got %v", err)
+	}
+	if _, err := runCLI(t, "list", "--slug-max-length", "-1"); err == nil {
+		t.Fatalf("expected negative --slug-max-length to fail")
+	}
+	for _, format := range []string{"text", "json", "yaml", "ndjson"} {
+		if _, err := runCLI(t, "list", "--theme", "birds", "--format", format, "--slug-style", "snake"); err == nil || !strings.Contains(err.Error(), "--format table or template") {
+			t.Fatalf("expected --slug-style to be rejected for list --format %s, got %v", format, err)
+		}
+	}
+}
+
 func TestValidateCommand(t *testing.T) {
 	_, err := runCLI(t, "validate", "Albatross", "--theme", "birds")
 	if err != nil {
@@ -149,11 +323,11 @@ func TestConfigShow_MissingFile(t *testing.T) {
 }
 
 func TestValidateCommand_AllThemes(t *testing.T) {
-	output, err := runCLI(t, "validate", "Almond")
+	_, stderr, err := runCLIStreams(t, "validate", "Almond")
 	if err != nil {
 		t.Fatalf("validate failed: %v", err)
 	}
-	if !strings.Contains(output, "crayola_colors") {
+	if !strings.Contains(stderr, "crayola_colors") {
 		t.Fatalf("expected Almond to be found in crayola_colors")
 	}
 }
@@ -218,6 +392,273 @@ func TestGenerateCommand_RecordCreatesConfig(t *testing.T) {
 	}
 }
 
+func TestGenerateCommand_RecordPreservesComments(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	original := "# release history\nschema_version: 2\ndefault_theme: birds # team pick\ndefault_format: text\n\nused_codenames:\n  0.1.0: Albatross\n"
+	if err := os.WriteFile(configPath, []byte(original), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := runCLI(t, "--config-path", configPath, "generate", "--seed", "1", "--exclude", "albatross", "--record")
+	if err != nil {
+		t.Fatalf("generate record failed: %v", err)
+	}
+
+	payload, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if !strings.HasPrefix(string(payload), original+"  unreleased:\n    codename: "+output+"\n") {
+		t.Fatalf("expected only the unreleased entry to be added, got:\n%s", payload)
+	}
+}
+
+func TestGenerateCommand_RecordFillsAuditDetails(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	if err := os.WriteFile(configPath, []byte("schema_version: 1\nused_codenames:\n    0.1.0: Albatross\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	deps := Dependencies{
+		Now: func() time.Time { return time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC) },
+		GitInfo: func(string) git.Info {
+			return git.Info{Commit: "0123456789abcdef0123456789abcdef01234567", UserEmail: "release@example.com"}
+		},
+	}
+	output, _, err := runCLIWith(t, deps, "--config-path", configPath, "generate", "--theme", "birds", "--seed", "7", "--record", "--note", "spring release")
+	if err != nil {
+		t.Fatalf("generate record failed: %v", err)
+	}
+
+	payload, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	expected := "schema_version: 2\n" +
+		"used_codenames:\n" +
+		"    0.1.0: Albatross\n" +
+		"    unreleased:\n" +
+		"        codename: " + output + "\n" +
+		"        theme: birds\n" +
+		"        seed: 7\n" +
+		"        recorded_at: 2026-03-01T12:30:00Z\n" +
+		"        commit: 0123456789abcdef0123456789abcdef01234567\n" +
+		"        recorded_by: release@example.com\n" +
+		"        notes: spring release\n" +
+		"default_theme: birds\n" +
+		"default_format: text\n"
+	if string(payload) != expected {
+		t.Fatalf("unexpected config:\n%s", payload)
+	}
+
+	if _, err := runCLI(t, "generate", "--note", "orphan"); err == nil {
+		t.Fatalf("expected --note without --record to fail")
+	}
+}
+
+func TestGenerateCommand_RecordVersion(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	if err := os.WriteFile(configPath, []byte("schema_version: 2\nused_codenames:\n    0.1.0: Albatross\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := runCLI(t, "--config-path", configPath, "generate", "--theme", "birds", "--seed", "3", "--record", "--version", "v0.2.0")
+	if err != nil {
+		t.Fatalf("generate record version failed: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.UsedCodenames["0.2.0"].Codename != output {
+		t.Fatalf("expected %q under 0.2.0, got %+v", output, cfg.UsedCodenames)
+	}
+	if _, ok := cfg.UsedCodenames[config.UnreleasedKey]; ok {
+		t.Fatalf("expected no unreleased entry, got %+v", cfg.UsedCodenames)
+	}
+
+	stdout, err := runCLI(t, "--config-path", configPath, "generate", "--theme", "birds", "--record", "--version", "0.1.0")
+	if err == nil || !strings.Contains(err.Error(), "already recorded") || clierror.Classify(err).Code != clierror.CodeConflict {
+		t.Fatalf("expected already recorded conflict, got %v", err)
+	}
+	if stdout != "" {
+		t.Fatalf("expected no codename on a conflict, got %q", stdout)
+	}
+	if _, err := runCLI(t, "--config-path", configPath, "generate", "--version", "0.3.0"); err == nil {
+		t.Fatalf("expected --version without --record to fail")
+	}
+	if _, err := runCLI(t, "--config-path", configPath, "generate", "--record", "--version", "next"); err == nil {
+		t.Fatalf("expected invalid version error")
+	}
+}
+
+func TestPromoteCommand(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	payload := "schema_version: 2\n" +
+		"used_codenames:\n" +
+		"    0.1.0: Albatross\n" +
+		"    # picked for the spring release\n" +
+		"    unreleased:\n" +
+		"        codename: Bittern\n" +
+		"        theme: birds\n" +
+		"default_theme: birds\n"
+	if err := os.WriteFile(configPath, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := runCLI(t, "--config-path", configPath, "promote", "0.2.0", "--dry-run")
+	if err != nil {
+		t.Fatalf("promote dry run failed: %v", err)
+	}
+	if !strings.Contains(output, "+    0.2.0:") || !strings.Contains(output, "-    unreleased:") {
+		t.Fatalf("expected diff in dry run output:\n%s", output)
+	}
+
+	if _, err := runCLI(t, "--config-path", configPath, "promote", "0.1.0"); err == nil || !strings.Contains(err.Error(), "already recorded") {
+		t.Fatalf("expected already recorded error, got %v", err)
+	}
+
+	output, stderr, err := runCLIStreams(t, "--config-path", configPath, "promote", "v0.2.0")
+	if err != nil {
+		t.Fatalf("promote failed: %v", err)
+	}
+	if output != "" || stderr != "Promoted Bittern to 0.2.0" {
+		t.Fatalf("unexpected promote output: %q, stderr %q", output, stderr)
+	}
+	written, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.UsedCodenames["0.2.0"] != (config.Record{Codename: "Bittern", Theme: "birds"}) {
+		t.Fatalf("expected promoted record, got %+v\n%s", cfg.UsedCodenames, written)
+	}
+	if _, ok := cfg.UsedCodenames[config.UnreleasedKey]; ok {
+		t.Fatalf("expected unreleased entry to be removed:\n%s", written)
+	}
+	if !strings.Contains(string(written), "default_theme: birds") {
+		t.Fatalf("expected other keys to be kept:\n%s", written)
+	}
+
+	if _, err := runCLI(t, "--config-path", configPath, "promote", "0.3.0"); err == nil || !strings.Contains(err.Error(), "no unreleased codename") {
+		t.Fatalf("expected missing unreleased error, got %v", err)
+	}
+	if _, err := runCLI(t, "--config-path", configPath, "promote", "next"); err == nil || clierror.Classify(err).Code != clierror.CodeUsage {
+		t.Fatalf("expected usage error for an invalid version, got %v", err)
+	}
+}
+
+func TestGenerateCommand_EnforcesPolicy(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	payload := "schema_version: 2\n" +
+		"used_codenames:\n" +
+		"    0.1.0: Crane\n" +
+		"policy:\n" +
+		"    letter_distance: 2\n" +
+		"    length:\n" +
+		"        - versions: \"*.0.0\"\n" +
+		"          max: 5\n" +
+		"    themes:\n" +
+		"        - versions: \"*-lts*\"\n" +
+		"          reserve: [cities]\n"
+	if err := os.WriteFile(configPath, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	for seed := 1; seed <= 20; seed++ {
+		output, err := runCLI(t, "--config-path", configPath, "generate", "--theme", "birds", "--seed", fmt.Sprint(seed))
+		if err != nil {
+			t.Fatalf("generate failed: %v", err)
+		}
+		if output != "Albatross" && output != "Eagle" {
+			t.Fatalf("seed %d: expected policy to exclude Blue Heron, Crane and Dove, got %q", seed, output)
+		}
+	}
+
+	// Major releases also drop names longer than five characters.
+	output, err := runCLI(t, "--config-path", configPath, "generate", "--theme", "birds", "--seed", "3", "--record", "--version", "1.0.0")
+	if err != nil {
+		t.Fatalf("generate with version failed: %v", err)
+	}
+	if output != "Eagle" {
+		t.Fatalf("expected Eagle for 1.0.0, got %q", output)
+	}
+
+	_, err = runCLI(t, "--config-path", configPath, "generate", "--theme", "cities")
+	if err == nil || !strings.Contains(err.Error(), `theme "cities" is reserved`) {
+		t.Fatalf("expected reserved theme error, got %v", err)
+	}
+}
+
+func TestValidateCommand_Policy(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	payload := "used_codenames:\n    0.1.0: Crane\npolicy:\n    letter_distance: 1\n    reuse_window: 3\n"
+	if err := os.WriteFile(configPath, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := runCLI(t, "--config-path", configPath, "validate", "Crane", "--theme", "birds", "--policy")
+	if err == nil {
+		t.Fatalf("expected policy failure")
+	}
+	for _, want := range []string{
+		`policy letter_distance: "Crane" starts with the same letter as the previous codename "Crane" (0.1.0)`,
+		`policy reuse_window: "Crane" was used for 0.1.0, within the last 3 releases`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output:\n%s", want, output)
+		}
+	}
+
+	_, stderr, err := runCLIStreams(t, "--config-path", configPath, "validate", "Dove", "--policy", "--version", "0.2.0")
+	if err != nil {
+		t.Fatalf("expected Dove to pass: %v", err)
+	}
+	if stderr != "Found in theme 'birds'\nPasses naming policy" {
+		t.Fatalf("unexpected output: %q", stderr)
+	}
+
+	if _, err := runCLI(t, "validate", "Dove", "--version", "0.2.0"); err == nil {
+		t.Fatalf("expected --version without --policy to fail")
+	}
+}
+
+func TestGenerateCommand_CIFormats(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "github_output")
+	t.Setenv("GITHUB_OUTPUT", outputPath)
+	t.Setenv("GITHUB_ENV", filepath.Join(dir, "github_env"))
+	t.Setenv("GITHUB_STEP_SUMMARY", filepath.Join(dir, "summary"))
+
+	name, err := runCLI(t, "generate", "--theme", "birds", "--seed", "4")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	output, err := runCLI(t, "generate", "--theme", "birds", "--seed", "4", "--format", "github")
+	if err != nil {
+		t.Fatalf("github format failed: %v", err)
+	}
+	written, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read $GITHUB_OUTPUT: %v", err)
+	}
+	if string(written) != output+"\n" || !strings.Contains(output, "name="+name+"\n") || !strings.HasSuffix(output, "theme=birds") {
+		t.Fatalf("unexpected github output %q (file %q)", output, written)
+	}
+
+	output, err = runCLI(t, "generate", "--theme", "birds", "--seed", "4", "--format", "gitlab-dotenv")
+	if err != nil {
+		t.Fatalf("gitlab-dotenv format failed: %v", err)
+	}
+	if !strings.Contains(output, "RELEASE_THEME=birds") {
+		t.Fatalf("unexpected dotenv output: %q", output)
+	}
+}
+
 func TestGenerateCommand_Formats(t *testing.T) {
 	jsonOutput, err := runCLI(t, "generate", "--theme", "birds", "--seed", "2", "--format", "json")
 	if err != nil {
@@ -286,3 +727,701 @@ func TestGenerateCommand_JSON_Golden(t *testing.T) {
 		t.Fatalf("generate json output mismatch\\nexpected: %s\\nactual:   %s", strings.TrimSpace(string(golden)), strings.TrimSpace(output))
 	}
 }
+
+func TestGenerateCommand_ExplainText(t *testing.T) {
+	stdout, stderr, err := runCLIStreams(t, "generate", "--theme", "birds", "--seed", "1", "--exclude", "heron", "--explain")
+	if err != nil {
+		t.Fatalf("generate explain failed: %v", err)
+	}
+
+	if strings.Contains(stdout, "theme:") {
+		t.Fatalf("expected explanation to stay off stdout, got %q", stdout)
+	}
+	for _, want := range []string{"theme: birds", "exclude: 5 -> 4", "Blue Heron", "seed: 1 (from flag)", "index:"} {
+		if !strings.Contains(stderr, want) {
+			t.Fatalf("expected explanation to contain %q, got:\n%s", want, stderr)
+		}
+	}
+}
+
+func TestGenerateCommand_ExplainJSON(t *testing.T) {
+	stdout, stderr, err := runCLIStreams(t, "generate", "--theme", "birds", "--seed", "1", "--format", "json", "--explain")
+	if err != nil {
+		t.Fatalf("generate explain failed: %v", err)
+	}
+	if stderr != "" {
+		t.Fatalf("expected no stderr output for json explain, got %q", stderr)
+	}
+
+	var decoded struct {
+		Name    string `json:"name"`
+		Explain struct {
+			Theme      string `json:"theme"`
+			Seed       int64  `json:"seed"`
+			SeedSource string `json:"seed_source"`
+			Selected   string `json:"selected"`
+		} `json:"explain"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.Explain.Theme != "birds" || decoded.Explain.Seed != 1 || decoded.Explain.SeedSource != "flag" {
+		t.Fatalf("unexpected explain payload: %+v", decoded.Explain)
+	}
+	if decoded.Explain.Selected != decoded.Name {
+		t.Fatalf("expected explain to reference selected name")
+	}
+}
+
+func TestGenerateCommand_UsesConfigDefaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tmp := t.TempDir()
+	configPath := filepath.Join(tmp, ".tagtastic.yaml")
+	if err := os.WriteFile(configPath, []byte("default_theme: birds\ndefault_format: shell\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := runCLI(t, "--config-path", configPath, "generate", "--seed", "42")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if output != "RELEASE_CODENAME=albatross" {
+		t.Fatalf("expected config theme and format to apply, got %q", output)
+	}
+
+	t.Setenv("TAGTASTIC_DEFAULT_FORMAT", "text")
+	output, err = runCLI(t, "--config-path", configPath, "generate", "--seed", "42")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if output != "Albatross" {
+		t.Fatalf("expected env to override config format, got %q", output)
+	}
+
+	output, err = runCLI(t, "--config-path", configPath, "list", "--theme", "cities", "--format", "json")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(output, "\"Kyoto\"") {
+		t.Fatalf("expected flags to override config, got %q", output)
+	}
+}
+
+func TestGenerateRecord_KeepsPersonalSettingsOutOfRepoConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".tagtastic"), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".tagtastic", "config.yaml"), []byte("default_format: yaml\n"), 0o600); err != nil {
+		t.Fatalf("write user config: %v", err)
+	}
+	t.Setenv("TAGTASTIC_DEFAULT_THEME", "cities")
+
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	if _, err := runCLI(t, "--config-path", configPath, "generate", "--seed", "42", "--record"); err != nil {
+		t.Fatalf("generate --record failed: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.DefaultTheme != "" || cfg.DefaultFormat != "" {
+		t.Fatalf("expected user and env settings to stay out of the repo config, got theme %q format %q", cfg.DefaultTheme, cfg.DefaultFormat)
+	}
+	if record := cfg.UsedCodenames[config.UnreleasedKey]; record.Theme != "cities" {
+		t.Fatalf("expected the codename to come from the env theme, got %+v", record)
+	}
+
+	if _, err := runCLI(t, "--config-path", configPath, "generate", "--seed", "42", "--record", "--theme", "birds", "--format", "text"); err != nil {
+		t.Fatalf("generate --record with flags failed: %v", err)
+	}
+	if cfg, err = config.Load(configPath); err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.DefaultTheme != "birds" || cfg.DefaultFormat != "text" {
+		t.Fatalf("expected flag values as repo defaults, got theme %q format %q", cfg.DefaultTheme, cfg.DefaultFormat)
+	}
+}
+
+func TestConfigShow_Effective(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".tagtastic"), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".tagtastic", "config.yaml"), []byte("default_format: json\n"), 0o600); err != nil {
+		t.Fatalf("write user config: %v", err)
+	}
+
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	if err := os.WriteFile(configPath, []byte("default_theme: birds\n"), 0o600); err != nil {
+		t.Fatalf("write repo config: %v", err)
+	}
+
+	output, err := runCLI(t, "config", "show", "--effective", "--path", configPath)
+	if err != nil {
+		t.Fatalf("config show --effective failed: %v", err)
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		switch fields[0] {
+		case "default_theme":
+			if fields[1] != "birds" || fields[2] != "repo" {
+				t.Fatalf("unexpected default_theme line: %q", line)
+			}
+		case "default_format":
+			if fields[1] != "json" || fields[2] != "user" {
+				t.Fatalf("unexpected default_format line: %q", line)
+			}
+		case "api.enabled":
+			if fields[1] != "false" || fields[2] != "default" {
+				t.Fatalf("unexpected api.enabled line: %q", line)
+			}
+		}
+	}
+}
+
+func TestConfigMigrate(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	legacy := []byte("0.1.0-beta.1: Almond\n")
+	if err := os.WriteFile(configPath, legacy, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, stderr, err := runCLIStreams(t, "config", "migrate", "--path", configPath, "--dry-run")
+	if err != nil {
+		t.Fatalf("config migrate dry-run failed: %v", err)
+	}
+	if !strings.Contains(output, "+schema_version: 2") || !strings.Contains(stderr, "legacy flat layout") {
+		t.Fatalf("expected diff and legacy warning, got:\n%s\n%s", output, stderr)
+	}
+	payload, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if string(payload) != string(legacy) {
+		t.Fatalf("expected dry-run to leave config untouched")
+	}
+
+	if _, err := runCLI(t, "config", "migrate", "--path", configPath); err != nil {
+		t.Fatalf("config migrate failed: %v", err)
+	}
+	payload, err = os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if !strings.Contains(string(payload), "used_codenames:") || !strings.Contains(string(payload), "0.1.0-beta.1: Almond") {
+		t.Fatalf("expected migrated config, got:\n%s", payload)
+	}
+
+	current := []byte("# release names\nschema_version: 2\nused_codenames:\n  0.1.0: Almond # first\n")
+	if err := os.WriteFile(configPath, current, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_, stderr, err = runCLIStreams(t, "config", "migrate", "--path", configPath)
+	if err != nil {
+		t.Fatalf("config migrate on current schema failed: %v", err)
+	}
+	if !strings.Contains(stderr, "already uses schema 2") {
+		t.Fatalf("expected no-op message, got %q", stderr)
+	}
+	if payload, _ := os.ReadFile(configPath); string(payload) != string(current) {
+		t.Fatalf("expected current config to be left alone, got:\n%s", payload)
+	}
+}
+
+func TestConfigSetGetUnset(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+
+	if _, err := runCLI(t, "config", "set", "default_theme", "birds", "--path", configPath); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if _, err := runCLI(t, "config", "set", "used_codenames.0.1.0-beta.2", "Apricot", "--path", configPath); err != nil {
+		t.Fatalf("config set used codename failed: %v", err)
+	}
+
+	output, err := runCLI(t, "config", "get", "default_theme", "--path", configPath)
+	if err != nil || output != "birds" {
+		t.Fatalf("expected birds, got %q (%v)", output, err)
+	}
+
+	output, err = runCLI(t, "config", "unset", "used_codenames.0.1.0-beta.2", "--path", configPath, "--dry-run")
+	if err != nil {
+		t.Fatalf("config unset dry-run failed: %v", err)
+	}
+	if !strings.Contains(output, "-    0.1.0-beta.2: Apricot") {
+		t.Fatalf("expected dry-run diff, got:\n%s", output)
+	}
+	output, err = runCLI(t, "config", "get", "used_codenames.0.1.0-beta.2", "--path", configPath)
+	if err != nil || output != "Apricot" {
+		t.Fatalf("expected dry-run to keep entry, got %q (%v)", output, err)
+	}
+
+	if _, err := runCLI(t, "config", "unset", "used_codenames.0.1.0-beta.2", "--path", configPath); err != nil {
+		t.Fatalf("config unset failed: %v", err)
+	}
+	if _, err := runCLI(t, "config", "get", "used_codenames.0.1.0-beta.2", "--path", configPath); err == nil {
+		t.Fatalf("expected error for removed entry")
+	}
+
+	if _, err := runCLI(t, "config", "set", "api.enabled", "maybe", "--path", configPath); err == nil || clierror.Classify(err).Code != clierror.CodeUsage {
+		t.Fatalf("expected usage error for api.enabled, got %v", err)
+	}
+}
+
+func TestConfigSetGetUnset_RecordFields(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	payload := "schema_version: 2\nused_codenames:\n  0.1.0: Albatross # first\n"
+	if err := os.WriteFile(configPath, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	if _, err := runCLI(t, "config", "set", "used_codenames.0.1.0.notes", "First flight", "--path", configPath); err != nil {
+		t.Fatalf("config set record field failed: %v", err)
+	}
+	output, err := runCLI(t, "config", "get", "used_codenames.0.1.0.notes", "--path", configPath)
+	if err != nil || output != "First flight" {
+		t.Fatalf("expected notes, got %q (%v)", output, err)
+	}
+	output, err = runCLI(t, "config", "get", "used_codenames.0.1.0.codename", "--path", configPath)
+	if err != nil || output != "Albatross" {
+		t.Fatalf("expected codename to survive, got %q (%v)", output, err)
+	}
+
+	if _, err := runCLI(t, "config", "unset", "used_codenames.0.1.0.notes", "--path", configPath); err != nil {
+		t.Fatalf("config unset record field failed: %v", err)
+	}
+	output, err = runCLI(t, "config", "get", "used_codenames.0.1.0", "--path", configPath)
+	if err != nil || output != "Albatross" {
+		t.Fatalf("expected plain entry after unset, got %q (%v)", output, err)
+	}
+
+	if _, err := runCLI(t, "config", "set", "used_codenames.0.2.0.notes", "x", "--path", configPath); err == nil {
+		t.Fatalf("expected error for a field of a missing entry")
+	}
+	if _, err := runCLI(t, "config", "get", "used_codenames.0.1.0.colour", "--path", configPath); err == nil || !strings.Contains(err.Error(), "record field") {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	payload := "schema_version: 1\ndefault_theme: birds\ndefault_format: json\nused_codenames:\n  0.1.0: Albatross\n"
+	if err := os.WriteFile(configPath, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := runCLI(t, "config", "validate", "--path", configPath, "--format", "json")
+	if err != nil {
+		t.Fatalf("expected valid config, got %v (%s)", err, output)
+	}
+	if output != "[]" {
+		t.Fatalf("expected no findings, got %s", output)
+	}
+
+	payload = "schema_version: 1\ndefault_theme: planets\nused_codenames:\n  0.1.0: Pluto\n"
+	if err := os.WriteFile(configPath, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err = runCLI(t, "config", "validate", "--path", configPath, "--format", "json")
+	if err == nil || clierror.Classify(err).Code != clierror.CodeConfigInvalid {
+		t.Fatalf("expected config_invalid error, got %v", err)
+	}
+
+	var findings []struct {
+		Severity string `json:"severity"`
+		Key      string `json:"key"`
+	}
+	if err := json.Unmarshal([]byte(output), &findings); err != nil {
+		t.Fatalf("unmarshal findings: %v (%s)", err, output)
+	}
+	keys := map[string]string{}
+	for _, finding := range findings {
+		keys[finding.Key] = finding.Severity
+	}
+	if keys["default_theme"] != "error" || keys["used_codenames.0.1.0"] != "warning" {
+		t.Fatalf("unexpected findings: %+v", findings)
+	}
+}
+
+func TestConfigDiscovery_MergesParentConfigs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TAGTASTIC_CONFIG", "")
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	service := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(service, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	rootConfig := filepath.Join(root, ".tagtastic.yaml")
+	if err := os.WriteFile(rootConfig, []byte("default_theme: birds\ndefault_format: json\n"), 0o600); err != nil {
+		t.Fatalf("write root config: %v", err)
+	}
+	serviceConfig := filepath.Join(service, ".tagtastic.yaml")
+	if err := os.WriteFile(serviceConfig, []byte("default_format: shell\n"), 0o600); err != nil {
+		t.Fatalf("write service config: %v", err)
+	}
+	t.Chdir(service)
+
+	output, err := runCLI(t, "generate", "--seed", "42")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if output != "RELEASE_CODENAME=albatross" {
+		t.Fatalf("expected root theme and nearest format, got %q", output)
+	}
+
+	output, err = runCLI(t, "config", "path")
+	if err != nil {
+		t.Fatalf("config path failed: %v", err)
+	}
+	var rootLine, serviceLine, middleLine string
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasSuffix(line, rootConfig):
+			rootLine = line
+		case strings.HasSuffix(line, serviceConfig):
+			serviceLine = line
+		case strings.HasSuffix(line, filepath.Join(root, "services", ".tagtastic.yaml")):
+			middleLine = line
+		}
+	}
+	if !strings.Contains(rootLine, "merged") || strings.Contains(rootLine, "primary") {
+		t.Fatalf("unexpected root line: %q", rootLine)
+	}
+	if !strings.Contains(serviceLine, "merged, primary") {
+		t.Fatalf("unexpected service line: %q", serviceLine)
+	}
+	if !strings.Contains(middleLine, "missing") {
+		t.Fatalf("unexpected middle line: %q", middleLine)
+	}
+}
+
+func TestConfigShow_JSONIncludesRecords(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	payload := "schema_version: 2\nused_codenames:\n  0.1.0: Albatross\n  0.2.0:\n    codename: Bittern\n    theme: birds\n    seed: 9\n"
+	if err := os.WriteFile(configPath, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := runCLI(t, "config", "show", "--path", configPath, "--format", "json")
+	if err != nil {
+		t.Fatalf("config show json failed: %v", err)
+	}
+
+	var decoded struct {
+		UsedCodenames map[string]map[string]any `json:"used_codenames"`
+	}
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.UsedCodenames["0.1.0"]["codename"] != "Albatross" {
+		t.Fatalf("expected plain entry as record, got %v", decoded.UsedCodenames["0.1.0"])
+	}
+	record := decoded.UsedCodenames["0.2.0"]
+	if record["codename"] != "Bittern" || record["theme"] != "birds" || record["seed"] != float64(9) {
+		t.Fatalf("unexpected record: %v", record)
+	}
+	if _, ok := record["recorded_at"]; ok {
+		t.Fatalf("expected unset recorded_at to be omitted: %v", record)
+	}
+}
+
+func TestHistoryCommand(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	payload := "schema_version: 2\nused_codenames:\n  0.10.0: Crane\n  0.2.0: Bittern\n  0.2.0-beta.1: Albatross\n  unreleased: Dove\n"
+	if err := os.WriteFile(configPath, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output, err := runCLI(t, "--config-path", configPath, "history", "--format", "csv", "--no-prerelease")
+	if err != nil {
+		t.Fatalf("history failed: %v", err)
+	}
+	expected := "version,codename,theme,seed,recorded_at,commit,recorded_by,notes\n0.2.0,Bittern,,,,,,\n0.10.0,Crane,,,,,,"
+	if output != expected {
+		t.Fatalf("unexpected history output:\n%s", output)
+	}
+
+	output, err = runCLI(t, "--config-path", configPath, "history", "--since", "0.2.0", "--limit", "1", "--format", "json")
+	if err != nil {
+		t.Fatalf("history json failed: %v", err)
+	}
+	var entries []map[string]any
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(entries) != 1 || entries[0]["version"] != "0.10.0" || entries[0]["codename"] != "Crane" {
+		t.Fatalf("unexpected entries: %v", entries)
+	}
+}
+
+func TestHistoryImport(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	changelog := "# Changelog\n\n## [Unreleased]\n\n## [0.3.0] – \"Cerulean\" – 2026-02-01\n\n## [0.2.0] – \"Bittersweet\" – 2026-01-15\n\n## [0.1.0] – \"Almond\" – 2026-01-01\n"
+	configPath := filepath.Join(dir, ".tagtastic.yaml")
+	files := map[string]string{
+		"CHANGELOG.md":    changelog,
+		".tagtastic.yaml": "schema_version: 2\n# releases\nused_codenames:\n    0.1.0: Almond\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "release@example.com"},
+		{"config", "user.name", "Release Bot"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+		{"tag", "-a", "v0.2.0", "-m", "v0.2.0 – Bittersweet"},
+		{"tag", "-a", "v0.3.0", "-m", "v0.3.0 – Denim"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	output, stderr, err := runCLIStreams(t, "history", "import", "--path", configPath, "--dry-run")
+	if err != nil {
+		t.Fatalf("history import dry run failed: %v", err)
+	}
+	for _, want := range []string{
+		"add\t0.2.0\tBittersweet\timported from changelog, tags",
+		`conflict	0.3.0: changelog="Cerulean" tags="Denim"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output:\n%s", want, output)
+		}
+	}
+	if want := "Dry run: 1 to add, 1 already recorded, 1 conflicts"; !strings.Contains(stderr, want) {
+		t.Fatalf("expected %q on stderr:\n%s", want, stderr)
+	}
+	if payload, _ := os.ReadFile(configPath); string(payload) != files[".tagtastic.yaml"] {
+		t.Fatalf("dry run modified config:\n%s", payload)
+	}
+
+	if _, err := runCLI(t, "history", "import", "--path", configPath, "--from", "tags,changelog"); err != nil {
+		t.Fatalf("history import failed: %v", err)
+	}
+	payload, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if !strings.Contains(string(payload), "# releases") || !strings.Contains(string(payload), "codename: Bittersweet") {
+		t.Fatalf("unexpected config after import:\n%s", payload)
+	}
+	if strings.Contains(string(payload), "0.3.0") {
+		t.Fatalf("conflicting release was written:\n%s", payload)
+	}
+
+	if _, err := runCLI(t, "history", "import", "--path", configPath, "--from", "branches"); err == nil {
+		t.Fatalf("expected unknown source error")
+	}
+}
+
+func TestVerbosity(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	output, stderr, err := runCLIStreams(t, "-q", "validate", "Almond")
+	if err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if output != "" || stderr != "" {
+		t.Fatalf("expected no output with -q, got %q and stderr %q", output, stderr)
+	}
+
+	want, err := runCLI(t, "--config-path", configPath, "generate", "--theme", "birds", "--seed", "1")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	output, stderr, err = runCLIStreams(t, "--config-path", configPath, "-v", "generate", "--theme", "birds", "--seed", "1")
+	if err != nil {
+		t.Fatalf("generate -v failed: %v", err)
+	}
+	if output != want {
+		t.Fatalf("expected -v to leave stdout unchanged, got %q", output)
+	}
+	if !strings.Contains(stderr, "msg=\"loaded config\"") || strings.Contains(stderr, "level=DEBUG") {
+		t.Fatalf("unexpected -v diagnostics:\n%s", stderr)
+	}
+
+	output, stderr, err = runCLIStreams(t, "--config-path", configPath, "--debug", "generate", "--theme", "birds", "--seed", "1")
+	if err != nil {
+		t.Fatalf("generate --debug failed: %v", err)
+	}
+	if output != want {
+		t.Fatalf("expected --debug to leave stdout unchanged, got %q", output)
+	}
+	for _, diagnostic := range []string{"resolved config path", "selected theme", "selection stage", "selected codename"} {
+		if !strings.Contains(stderr, diagnostic) {
+			t.Fatalf("expected %q in --debug diagnostics:\n%s", diagnostic, stderr)
+		}
+	}
+}
+
+func TestNewCLI_SharesOneLogger(t *testing.T) {
+	var errOut bytes.Buffer
+	app := NewCLI(Dependencies{Err: &errOut})
+	logger := app.Generate.deps.Logger
+	if logger == nil || logger != app.Config.Set.deps.Logger || logger != app.Release.deps.logger() || logger != app.Logger() {
+		t.Fatalf("expected every command to share the logger built by NewCLI")
+	}
+	if logger.Enabled(context.Background(), slog.LevelInfo) {
+		t.Fatalf("expected diagnostics to be off before flags are parsed")
+	}
+
+	parser, err := kong.New(app, kong.Name("tagtastic"))
+	if err != nil {
+		t.Fatalf("new parser: %v", err)
+	}
+	if _, err := parser.Parse([]string{"--debug", "version"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatalf("expected --debug to enable debug diagnostics on the shared logger")
+	}
+}
+
+func TestReleaseCommand(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"CHANGELOG.md":    "# Changelog\n\n## [Unreleased]\n\n### Added\n- Releases.\n\n## [0.1.0] – \"Albatross\" – 2026-01-01\n",
+		"VERSION":         "0.1.0\n",
+		".tagtastic.yaml": "default_theme: birds\nrepository:\n  url: https://gitlab.com/example/birds\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	t.Chdir(dir)
+
+	payload, stderr, err := runCLIStreams(t, "release", "--bump", "minor", "--date", "2026-03-01", "--dry-run", "--format", "json")
+	if err != nil {
+		t.Fatalf("release dry run failed: %v", err)
+	}
+	var result output.Result
+	if err := json.Unmarshal([]byte(payload), &result); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, payload)
+	}
+	if result.Name != "Blue Heron" || result.Slug != "blue-heron" || result.Version != "0.2.0" || result.Theme != "birds" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	for _, want := range []string{"Dry run: would prepare v0.2.0 – Blue Heron", "would link changelog references to https://gitlab.com/example/birds"} {
+		if !strings.Contains(stderr, want) {
+			t.Fatalf("expected %q on stderr:\n%s", want, stderr)
+		}
+	}
+	if payload, _ := os.ReadFile("VERSION"); string(payload) != "0.1.0\n" {
+		t.Fatalf("dry run modified VERSION: %q", payload)
+	}
+
+	if payload, stderr, err = runCLIStreams(t, "-q", "release", "0.1.1", "--codename", "Dove", "--dry-run"); err != nil {
+		t.Fatalf("quiet release dry run failed: %v", err)
+	}
+	if payload != "" || stderr != "" {
+		t.Fatalf("expected no output with -q, got %q and stderr %q", payload, stderr)
+	}
+
+	payload, err = runCLI(t, "release", "0.2.0", "--theme", "crayola_colors", "--seed", "7", "--dry-run", "--format", "json")
+	if err != nil {
+		t.Fatalf("seeded release dry run failed: %v", err)
+	}
+	result = output.Result{}
+	if err := json.Unmarshal([]byte(payload), &result); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, payload)
+	}
+	if result.Theme != "crayola_colors" || result.Seed != 7 || result.SeedSource != selection.SeedFromFlag || result.Attributes[data.AttrHex] == "" {
+		t.Fatalf("unexpected seeded result: %+v", result)
+	}
+
+	if _, err := runCLI(t, "release"); err == nil || !strings.Contains(err.Error(), "version is required") || clierror.Classify(err).Code != clierror.CodeUsage {
+		t.Fatalf("expected missing version usage error, got %v", err)
+	}
+	if _, err := runCLI(t, "release", "0.1.0", "--dry-run"); err == nil || !strings.Contains(err.Error(), "greater than 0.1.0") || clierror.Classify(err).Code != clierror.CodeUsage {
+		t.Fatalf("expected non-forward version usage error, got %v", err)
+	}
+	if _, err := runCLI(t, "release", "0.2.0"); err == nil || clierror.Classify(err).Code != clierror.CodeGit {
+		t.Fatalf("expected a git error outside a repository, got %v", err)
+	}
+}
+
+func TestReleasePromotesRecordedCodename(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"CHANGELOG.md":    "# Changelog\n\n## [Unreleased]\n\n### Added\n- Birds.\n\n## [0.1.0] – \"Albatross\" – 2026-01-01\n",
+		"VERSION":         "0.1.0\n",
+		".tagtastic.yaml": "default_theme: birds\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "release@example.com"},
+		{"config", "user.name", "Release Bot"},
+		{"add", "."},
+		{"commit", "-q", "-m", "initial"},
+		{"tag", "-a", "v0.1.0", "-m", "v0.1.0 – Albatross"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	t.Chdir(dir)
+
+	recorded, err := runCLI(t, "generate", "--seed", "3", "--record")
+	if err != nil {
+		t.Fatalf("generate --record failed: %v", err)
+	}
+	payload, err := runCLI(t, "-q", "release", "--bump", "minor", "--dry-run", "--format", "json")
+	if err != nil {
+		t.Fatalf("release dry run failed: %v", err)
+	}
+	var result output.Result
+	if err := json.Unmarshal([]byte(payload), &result); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, payload)
+	}
+	if result.Name != recorded || result.Seed != 0 || result.SeedSource != "" {
+		t.Fatalf("expected the promoted codename without a seed source, got %+v", result)
+	}
+	if _, err := runCLI(t, "-q", "release", "--bump", "minor"); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+
+	cfg, err := config.Load(".tagtastic.yaml")
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if _, ok := cfg.UsedCodenames[config.UnreleasedKey]; ok {
+		t.Fatalf("expected the unreleased record to be promoted, got %+v", cfg.UsedCodenames)
+	}
+	if record := cfg.UsedCodenames["0.2.0"]; record.Codename != recorded || record.Theme != "birds" {
+		t.Fatalf("expected 0.2.0 to be released as %q, got %+v", recorded, record)
+	}
+	changelog, err := os.ReadFile("CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("read changelog: %v", err)
+	}
+	if !strings.Contains(string(changelog), fmt.Sprintf("## [0.2.0] – %q", recorded)) {
+		t.Fatalf("expected the recorded codename in the changelog:\n%s", changelog)
+	}
+}
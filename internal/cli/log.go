@@ -0,0 +1,93 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+)
+
+// Verbosity controls what is written to stderr besides errors and warnings:
+// informational messages at VerbosityNormal and above, and slog diagnostics
+// at VerbosityVerbose (info) and VerbosityDebug (debug).
+type Verbosity int
+
+const (
+	VerbosityQuiet Verbosity = iota - 1
+	VerbosityNormal
+	VerbosityVerbose
+	VerbosityDebug
+)
+
+// levelSilent is above every slog level, so nothing is logged.
+const levelSilent = slog.Level(math.MaxInt)
+
+// logLevel returns the lowest slog level logged at verbosity.
+func logLevel(verbosity Verbosity) slog.Level {
+	switch {
+	case verbosity >= VerbosityDebug:
+		return slog.LevelDebug
+	case verbosity == VerbosityVerbose:
+		return slog.LevelInfo
+	default:
+		return levelSilent
+	}
+}
+
+// Logger returns the diagnostics logger shared with every command, for
+// callers that log outside a command run. It logs nothing until the
+// arguments are parsed.
+func (c *CLI) Logger() *slog.Logger {
+	return c.logger
+}
+
+// AfterApply sets the level of the shared diagnostics logger once the
+// global flags are parsed.
+func (c *CLI) AfterApply() error {
+	if c.logLevel != nil {
+		c.logLevel.Set(logLevel(c.verbosity()))
+	}
+	return nil
+}
+
+func (c *CLI) verbosity() Verbosity {
+	switch {
+	case c.Debug:
+		return VerbosityDebug
+	case c.Verbose:
+		return VerbosityVerbose
+	case c.Quiet:
+		return VerbosityQuiet
+	default:
+		return VerbosityNormal
+	}
+}
+
+func (deps Dependencies) verbosity() Verbosity {
+	if deps.VerbosityResolver == nil {
+		return VerbosityNormal
+	}
+	return deps.VerbosityResolver()
+}
+
+// logger returns the diagnostics logger, or one that discards everything
+// when deps were not built by NewCLI.
+func (deps Dependencies) logger() *slog.Logger {
+	if deps.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return deps.Logger
+}
+
+// infof writes an informational message to stderr unless -q was given.
+// Command results go to deps.Out instead.
+func infof(deps Dependencies, format string, args ...any) {
+	if deps.verbosity() == VerbosityQuiet {
+		return
+	}
+	_, _ = fmt.Fprintf(deps.Err, format+"\n", args...)
+}
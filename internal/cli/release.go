@@ -0,0 +1,178 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/infravillage/tagtastic/internal/clierror"
+	"github.com/infravillage/tagtastic/internal/config"
+	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/output"
+	"github.com/infravillage/tagtastic/internal/release"
+	"github.com/infravillage/tagtastic/internal/selection"
+)
+
+type ReleaseCmd struct {
+	Version        string `arg:"" optional:"" help:"Release version (SemVer); omit to use --bump"`
+	Bump           string `long:"bump" help:"Bump the latest tagged or VERSION version (major, minor, patch)"`
+	Pre            string `long:"pre" help:"Prerelease label (alpha, beta, rc)"`
+	PreNum         int    `long:"pre-num" help:"Prerelease number (defaults to next available)"`
+	Codename       string `long:"codename" help:"Codename override (defaults to the next unused name in the theme)"`
+	Theme          string `short:"t" long:"theme" help:"Theme to pick the codename from (defaults to config, then crayola_colors)"`
+	Random         bool   `long:"random" help:"Draw the codename at random like generate instead of in theme order"`
+	Seed           int64  `short:"s" long:"seed" help:"Random seed for the codename draw (implies --random; 0 uses time)"`
+	Date           string `long:"date" help:"Release date (YYYY-MM-DD), defaults to today"`
+	Commit         bool   `long:"commit" help:"Commit CHANGELOG.md and VERSION updates"`
+	DryRun         bool   `long:"dry-run" help:"Preview changes without writing files or tagging"`
+	NoConfigUpdate bool   `long:"no-config-update" help:"Skip recording the release in repo config"`
+	Note           string `long:"note" help:"Note stored with the release record in repo config"`
+	Path           string `short:"p" long:"path" help:"Config file to record the release in"`
+	Format         string `short:"f" long:"format" help:"Also print the release as a result in this format (json, yaml, github, ...)"`
+	deps           Dependencies
+}
+
+func (cmd ReleaseCmd) Run() error {
+	var formatter output.Formatter
+	if cmd.Format != "" {
+		var err error
+		if formatter, err = cmd.deps.FormatterFactory(cmd.Format); err != nil {
+			return err
+		}
+	}
+
+	root, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	path, err := configCommandPath(cmd.deps, cmd.Path)
+	if err != nil {
+		return err
+	}
+	settings, err := resolveSettings(cmd.deps, cmd.Path, map[string]string{"default_theme": cmd.Theme})
+	if err != nil {
+		return err
+	}
+
+	opts := release.Options{
+		Dir:            root,
+		Version:        cmd.Version,
+		Bump:           cmd.Bump,
+		Pre:            cmd.Pre,
+		PreNum:         cmd.PreNum,
+		Codename:       cmd.Codename,
+		Themes:         cmd.deps.Themes,
+		Theme:          settings.Get("default_theme"),
+		Random:         cmd.Random,
+		Seed:           cmd.Seed,
+		Date:           cmd.Date,
+		ConfigPath:     path,
+		Note:           cmd.Note,
+		Commit:         cmd.Commit,
+		NoConfigUpdate: cmd.NoConfigUpdate,
+		Now:            cmd.deps.Now,
+		GitInfo:        cmd.deps.GitInfo,
+		Output:         cmd.deps.Err,
+		Warn: func(path string, warnings []config.Warning) {
+			printWarnings(cmd.deps, path, warnings)
+		},
+		Logger: cmd.deps.logger(),
+	}
+	plan, err := release.Prepare(opts)
+	if err != nil {
+		return releaseError(err)
+	}
+	item, theme := cmd.lookup(plan)
+	if plan.Theme == "" {
+		plan.Theme = theme
+	}
+
+	if cmd.DryRun {
+		infof(cmd.deps, "Dry run: would prepare v%s – %s", plan.Version, plan.Codename)
+		infof(cmd.deps, "Dry run: would update CHANGELOG.md and VERSION (date %s)", plan.Date)
+		if !plan.Links.IsZero() {
+			infof(cmd.deps, "Dry run: would link changelog references to %s", plan.Links.URL)
+		}
+		infof(cmd.deps, "Dry run: would create tag v%s", plan.Version)
+		if !cmd.NoConfigUpdate {
+			infof(cmd.deps, "Dry run: would update config at %s", path)
+			if plan.Promote {
+				infof(cmd.deps, "Dry run: would promote the unreleased codename to %s", plan.Version)
+			}
+		}
+	} else {
+		if err := release.Apply(opts, plan); err != nil {
+			return releaseError(err)
+		}
+		infof(cmd.deps, "Prepared release v%s – %s", plan.Version, plan.Codename)
+	}
+
+	if formatter == nil {
+		return nil
+	}
+	result := output.NewResult(item)
+	result.Theme = plan.Theme
+	result.Version = plan.Version
+	// A promoted codename was drawn by an earlier generate run; records do
+	// not keep where its seed came from, so neither is reported.
+	if plan.Seed != 0 && !plan.Promote {
+		result.Seed, result.SeedSource = plan.Seed, selection.SeedFromFlag
+		if cmd.Seed == 0 {
+			result.SeedSource = selection.SeedFromTime
+		}
+	}
+	version := cmd.deps.VersionInfo.withDefaults()
+	result.Generator.Version = version.Version
+	if version.Commit != "none" {
+		result.Generator.Commit = version.Commit
+	}
+	rendered, err := formatter.FormatResult(result)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(cmd.deps.Out, rendered)
+	return nil
+}
+
+// releaseError gives the release package's errors their CLI codes. Other
+// errors are left to clierror.Classify.
+func releaseError(err error) error {
+	switch {
+	case errors.Is(err, release.ErrUsage):
+		return clierror.New(clierror.CodeUsage, err, "")
+	case errors.Is(err, release.ErrGit):
+		return clierror.New(clierror.CodeGit, err, "")
+	case errors.Is(err, release.ErrRepository):
+		return clierror.New(clierror.CodeConfigInvalid, err, "run `tagtastic config validate` for details")
+	default:
+		return err
+	}
+}
+
+// lookup finds the release codename in the theme repository, so results
+// carry its slug and attributes and a --codename override is recorded with
+// its theme. Names found in no theme are returned as is.
+func (cmd ReleaseCmd) lookup(plan release.Plan) (data.CodeName, string) {
+	names := cmd.deps.Themes.GetAllThemeNames()
+	if plan.Theme != "" {
+		names = []string{plan.Theme}
+	}
+	needle := data.NormalizeName(plan.Codename)
+	for _, name := range names {
+		theme, err := cmd.deps.Themes.GetThemeByName(name)
+		if err != nil {
+			continue
+		}
+		for _, item := range theme.Items {
+			if data.NormalizeName(item.Name) == needle {
+				return item, theme.ID
+			}
+		}
+	}
+	return data.CodeName{Name: plan.Codename}, ""
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+// Package clierror classifies command errors into stable codes with exit
+// statuses and hints, and reports them as text or JSON.
+package clierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/config"
+	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/history"
+	"github.com/infravillage/tagtastic/internal/output"
+	"github.com/infravillage/tagtastic/internal/policy"
+	"github.com/infravillage/tagtastic/internal/selection"
+	"github.com/infravillage/tagtastic/internal/slug"
+)
+
+// Code identifies a kind of failure. Codes and their exit statuses are part
+// of the CLI contract and do not change between releases.
+type Code string
+
+const (
+	CodeRuntime         Code = "runtime"
+	CodeUsage           Code = "usage"
+	CodeThemeNotFound   Code = "theme_not_found"
+	CodeUnknownFormat   Code = "unknown_format"
+	CodePoolExhausted   Code = "pool_exhausted"
+	CodePolicyViolation Code = "policy_violation"
+	CodeConfigInvalid   Code = "config_invalid"
+	CodeIO              Code = "io"
+	CodeConflict        Code = "conflict"
+	CodeGit             Code = "git"
+)
+
+// exitCodes maps each code to its process exit status.
+var exitCodes = map[Code]int{
+	CodeRuntime:         1,
+	CodeUsage:           2,
+	CodeThemeNotFound:   3,
+	CodeUnknownFormat:   4,
+	CodePoolExhausted:   5,
+	CodePolicyViolation: 6,
+	CodeConfigInvalid:   7,
+	CodeIO:              8,
+	CodeConflict:        9,
+	CodeGit:             10,
+}
+
+// ExitCode returns the exit status for code.
+func ExitCode(code Code) int {
+	if exit, ok := exitCodes[code]; ok {
+		return exit
+	}
+	return exitCodes[CodeRuntime]
+}
+
+// Error is an error with a code and an optional hint for the user.
+type Error struct {
+	Code Code
+	Hint string
+	Err  error
+}
+
+// New wraps err with code and hint.
+func New(code Code, err error, hint string) *Error {
+	return &Error{Code: code, Hint: hint, Err: err}
+}
+
+// Usagef returns a usage error, for invalid flags and arguments.
+func Usagef(format string, args ...any) *Error {
+	return New(CodeUsage, fmt.Errorf(format, args...), "")
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit status for the error.
+func (e *Error) ExitCode() int {
+	return ExitCode(e.Code)
+}
+
+// Classify returns err as an *Error. Errors that already carry a code keep
+// it; known sentinel errors get their code and hint; anything else is a
+// runtime error.
+func Classify(err error) *Error {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded
+	}
+
+	var pathErr *fs.PathError
+	switch {
+	case errors.Is(err, data.ErrThemeNotFound):
+		return New(CodeThemeNotFound, err, "run `tagtastic themes` to list the available themes")
+	case errors.Is(err, output.ErrUnknownFormat):
+		return New(CodeUnknownFormat, err, "supported formats: "+strings.Join(output.Formats(), ", "))
+	case errors.Is(err, history.ErrUnknownFormat):
+		return New(CodeUnknownFormat, err, "supported formats: "+strings.Join(history.Formats, ", "))
+	case errors.Is(err, output.ErrTemplateRequired):
+		return New(CodeUsage, err, "")
+	case errors.Is(err, slug.ErrInvalid):
+		return New(CodeUsage, err, "choose another --slug-style or raise --slug-max-length")
+	case errors.Is(err, selection.ErrPoolExhausted):
+		return New(CodePoolExhausted, err, "relax --exclude or --where, or run with --explain to see what was filtered out")
+	case errors.Is(err, policy.ErrViolation):
+		return New(CodePolicyViolation, err, "run `tagtastic validate <name> --policy` to check names against the policy")
+	case errors.Is(err, config.ErrRecorded):
+		return New(CodeConflict, err, "recorded releases are not overwritten; use another version")
+	case errors.Is(err, config.ErrParse):
+		return New(CodeConfigInvalid, err, "run `tagtastic config validate` for details")
+	case errors.As(err, &pathErr):
+		return New(CodeIO, err, "")
+	default:
+		return New(CodeRuntime, err, "")
+	}
+}
+
+// Report writes err to w, as one JSON object when jsonErrors is set, and
+// returns the exit status. The JSON form is
+//
+//	{"error":"theme not found: planets","type":"theme_not_found","code":3,"hint":"..."}
+//
+// where type is the stable Code and code the exit status.
+func Report(w io.Writer, err error, jsonErrors bool) int {
+	classified := Classify(err)
+	if jsonErrors {
+		payload, _ := json.Marshal(struct {
+			Error string `json:"error"`
+			Type  Code   `json:"type"`
+			Code  int    `json:"code"`
+			Hint  string `json:"hint,omitempty"`
+		}{
+			Error: classified.Error(),
+			Type:  classified.Code,
+			Code:  classified.ExitCode(),
+			Hint:  classified.Hint,
+		})
+		_, _ = fmt.Fprintln(w, string(payload))
+		return classified.ExitCode()
+	}
+
+	_, _ = fmt.Fprintf(w, "error: %v\n", classified)
+	if classified.Hint != "" {
+		_, _ = fmt.Fprintf(w, "hint: %s\n", classified.Hint)
+	}
+	return classified.ExitCode()
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package clierror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/config"
+	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/output"
+	"github.com/infravillage/tagtastic/internal/policy"
+	"github.com/infravillage/tagtastic/internal/selection"
+)
+
+func TestClassify(t *testing.T) {
+	_, readErr := os.ReadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	_, _, parseErr := config.Decode([]byte("a: [\n"))
+
+	cases := []struct {
+		err  error
+		code Code
+		exit int
+	}{
+		{err: fmt.Errorf("load: %w", data.ErrThemeNotFound), code: CodeThemeNotFound, exit: 3},
+		{err: output.ErrUnknownFormat, code: CodeUnknownFormat, exit: 4},
+		{err: selection.ErrPoolExhausted, code: CodePoolExhausted, exit: 5},
+		{err: fmt.Errorf("%w: length", policy.ErrViolation), code: CodePolicyViolation, exit: 6},
+		{err: parseErr, code: CodeConfigInvalid, exit: 7},
+		{err: fmt.Errorf("read config: %w", readErr), code: CodeIO, exit: 8},
+		{err: Usagef("--note requires --record"), code: CodeUsage, exit: 2},
+		{err: New(CodeGit, errors.New("git tag failed"), ""), code: CodeGit, exit: 10},
+		{err: errors.New("boom"), code: CodeRuntime, exit: 1},
+	}
+	for _, tc := range cases {
+		got := Classify(tc.err)
+		if got.Code != tc.code || got.ExitCode() != tc.exit {
+			t.Fatalf("%v: expected %s/%d, got %s/%d", tc.err, tc.code, tc.exit, got.Code, got.ExitCode())
+		}
+		if got.Error() != tc.err.Error() {
+			t.Fatalf("expected the message to be kept, got %q", got.Error())
+		}
+	}
+}
+
+func TestExitCodesAreDistinct(t *testing.T) {
+	seen := map[int]Code{}
+	for code, exit := range exitCodes {
+		if other, ok := seen[exit]; ok {
+			t.Fatalf("%s and %s share exit code %d", code, other, exit)
+		}
+		seen[exit] = code
+	}
+}
+
+func TestReport(t *testing.T) {
+	var buf bytes.Buffer
+	exit := Report(&buf, fmt.Errorf("%w: planets", data.ErrThemeNotFound), true)
+	if exit != 3 {
+		t.Fatalf("expected exit 3, got %d", exit)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal %q: %v", buf.String(), err)
+	}
+	if payload["error"] != "theme not found: planets" || payload["type"] != "theme_not_found" || payload["code"] != float64(3) || !strings.Contains(payload["hint"].(string), "tagtastic themes") {
+		t.Fatalf("unexpected payload: %v", payload)
+	}
+
+	buf.Reset()
+	if exit := Report(&buf, errors.New("boom"), true); exit != 1 || strings.TrimSpace(buf.String()) != `{"error":"boom","type":"runtime","code":1}` {
+		t.Fatalf("unexpected runtime report %d %q", exit, buf.String())
+	}
+
+	buf.Reset()
+	Report(&buf, selection.ErrPoolExhausted, false)
+	if !strings.HasPrefix(buf.String(), "error: no available codenames after exclusions\nhint: ") {
+		t.Fatalf("unexpected text report %q", buf.String())
+	}
+}
@@ -16,17 +16,20 @@ import (
 )
 
 type Config struct {
-	DefaultTheme  string            `yaml:"default_theme"`
-	DefaultFormat string            `yaml:"default_format"`
-	UsedCodenames map[string]string `yaml:"used_codenames"`
-	API           APIConfig         `yaml:"api"`
+	SchemaVersion int               `yaml:"schema_version" json:"schema_version"`
+	DefaultTheme  string            `yaml:"default_theme" json:"default_theme"`
+	DefaultFormat string            `yaml:"default_format" json:"default_format"`
+	UsedCodenames map[string]Record `yaml:"used_codenames" json:"used_codenames"`
+	API           APIConfig         `yaml:"api" json:"api"`
+	Policy        Policy            `yaml:"policy,omitempty" json:"policy,omitzero"`
+	Repository    Repository        `yaml:"repository,omitempty" json:"repository,omitzero"`
 }
 
 type APIConfig struct {
-	Enabled  bool   `yaml:"enabled"`
-	Endpoint string `yaml:"endpoint"`
-	CacheDir string `yaml:"cache_dir"`
-	CacheTTL string `yaml:"cache_ttl"`
+	Enabled  bool   `yaml:"enabled" json:"enabled"`
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+	CacheDir string `yaml:"cache_dir" json:"cache_dir"`
+	CacheTTL string `yaml:"cache_ttl" json:"cache_ttl"`
 }
 
 func DefaultPath() (string, error) {
@@ -39,9 +42,10 @@ func DefaultPath() (string, error) {
 
 func Default() Config {
 	return Config{
+		SchemaVersion: CurrentSchemaVersion,
 		DefaultTheme:  "crayola_colors",
 		DefaultFormat: "text",
-		UsedCodenames: map[string]string{},
+		UsedCodenames: map[string]Record{},
 		API: APIConfig{
 			Enabled:  false,
 			Endpoint: "",
@@ -51,7 +55,9 @@ func Default() Config {
 	}
 }
 
+// Marshal encodes cfg in the current schema layout.
 func Marshal(cfg Config) ([]byte, error) {
+	cfg.SchemaVersion = CurrentSchemaVersion
 	return yaml.Marshal(cfg)
 }
 
@@ -73,25 +79,27 @@ func ResolvePath(path string) (string, error) {
 }
 
 func Load(path string) (Config, error) {
+	cfg, _, err := LoadWithWarnings(path)
+	return cfg, err
+}
+
+// LoadWithWarnings is like Load but also reports unknown keys and legacy
+// layouts that were converted while reading.
+func LoadWithWarnings(path string) (Config, []Warning, error) {
 	resolved, err := ResolvePath(path)
 	if err != nil {
-		return Config{}, err
+		return Config{}, nil, err
 	}
 
 	payload, err := os.ReadFile(resolved)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return Config{}, nil
+			return Config{}, nil, nil
 		}
-		return Config{}, fmt.Errorf("read config: %w", err)
-	}
-
-	var cfg Config
-	if err := yaml.Unmarshal(payload, &cfg); err != nil {
-		return Config{}, fmt.Errorf("parse config: %w", err)
+		return Config{}, nil, fmt.Errorf("read config: %w", err)
 	}
 
-	return cfg, nil
+	return Decode(payload)
 }
 
 func expandHome(path string) (string, error) {
@@ -28,7 +28,7 @@ func TestLoadConfig(t *testing.T) {
 	if cfg.DefaultTheme != "birds" {
 		t.Fatalf("expected default_theme birds, got %q", cfg.DefaultTheme)
 	}
-	if cfg.UsedCodenames["v1.0.0"] != "Almond" {
+	if cfg.UsedCodenames["v1.0.0"].Codename != "Almond" {
 		t.Fatalf("expected used codename Almond")
 	}
 }
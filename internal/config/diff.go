@@ -0,0 +1,152 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// Diff returns a unified diff between two payloads, or an empty string when
+// they are identical. It is intended for small files such as .tagtastic.yaml.
+func Diff(fromName, toName string, from, to []byte) string {
+	a := splitLines(string(from))
+	b := splitLines(string(to))
+	ops := diffLines(a, b)
+
+	changed := false
+	for _, op := range ops {
+		if op.kind != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+
+	for start := 0; start < len(ops); {
+		first := -1
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				first = i
+				break
+			}
+		}
+		if first == -1 {
+			break
+		}
+
+		hunkStart := max(first-diffContext, start)
+		hunkEnd := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				hunkEnd = i
+				continue
+			}
+			if i-hunkEnd > 2*diffContext {
+				break
+			}
+		}
+		hunkEnd = min(hunkEnd+diffContext, len(ops)-1)
+
+		aLine, bLine := 0, 0
+		for _, op := range ops[:hunkStart] {
+			if op.kind != '+' {
+				aLine++
+			}
+			if op.kind != '-' {
+				bLine++
+			}
+		}
+		aLen, bLen := 0, 0
+		for _, op := range ops[hunkStart : hunkEnd+1] {
+			if op.kind != '+' {
+				aLen++
+			}
+			if op.kind != '-' {
+				bLen++
+			}
+		}
+		if aLen > 0 {
+			aLine++
+		}
+		if bLen > 0 {
+			bLine++
+		}
+
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", aLine, aLen, bLine, bLen)
+		for _, op := range ops[hunkStart : hunkEnd+1] {
+			out.WriteByte(op.kind)
+			out.WriteString(op.text)
+			out.WriteByte('\n')
+		}
+
+		start = hunkEnd + 1
+	}
+
+	return out.String()
+}
+
+func splitLines(content string) []string {
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
+
+// diffLines computes an edit script using the longest common subsequence.
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{kind: '-', text: a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{kind: '-', text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{kind: '+', text: b[j]})
+	}
+
+	return ops
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// RepoConfigName is the file name of a repository config.
+const RepoConfigName = ".tagtastic.yaml"
+
+// Discovery lists the repository config locations between a start directory
+// and the enclosing git repository root.
+type Discovery struct {
+	Start string
+	// Root is the git repository root, or empty when start is not inside a
+	// repository.
+	Root string
+	// Candidates are .tagtastic.yaml paths ordered from the start directory
+	// up to Root.
+	Candidates []string
+}
+
+// Discover walks from start up to the git repository root collecting
+// candidate config paths. Outside a repository only start is considered.
+func Discover(start string) (Discovery, error) {
+	abs, err := filepath.Abs(start)
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	discovery := Discovery{Start: abs}
+	root := gitRoot(abs)
+	if root == "" {
+		discovery.Candidates = []string{filepath.Join(abs, RepoConfigName)}
+		return discovery, nil
+	}
+	discovery.Root = root
+
+	for dir := abs; ; dir = filepath.Dir(dir) {
+		discovery.Candidates = append(discovery.Candidates, filepath.Join(dir, RepoConfigName))
+		if dir == root {
+			break
+		}
+	}
+
+	return discovery, nil
+}
+
+// Found returns the candidates that exist, nearest first.
+func (d Discovery) Found() []string {
+	var found []string
+	for _, candidate := range d.Candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			found = append(found, candidate)
+		}
+	}
+	return found
+}
+
+// Nearest returns the closest existing config. When none exists it returns
+// the path at the repository root, or in start outside a repository.
+func (d Discovery) Nearest() string {
+	if found := d.Found(); len(found) > 0 {
+		return found[0]
+	}
+	return d.Candidates[len(d.Candidates)-1]
+}
+
+func gitRoot(start string) string {
+	for dir := start; ; {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
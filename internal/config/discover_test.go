@@ -0,0 +1,72 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiscover_WalksToGitRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	discovery, err := Discover(nested)
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	if discovery.Root != root {
+		t.Fatalf("expected root %s, got %s", root, discovery.Root)
+	}
+	expected := []string{
+		filepath.Join(nested, RepoConfigName),
+		filepath.Join(root, "a", RepoConfigName),
+		filepath.Join(root, RepoConfigName),
+	}
+	if !reflect.DeepEqual(discovery.Candidates, expected) {
+		t.Fatalf("unexpected candidates: %v", discovery.Candidates)
+	}
+
+	if got := discovery.Nearest(); got != filepath.Join(root, RepoConfigName) {
+		t.Fatalf("expected root config when none exist, got %s", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, RepoConfigName), []byte("{}\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "a", RepoConfigName), []byte("{}\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if got := discovery.Nearest(); got != filepath.Join(root, "a", RepoConfigName) {
+		t.Fatalf("expected nearest config, got %s", got)
+	}
+	if found := discovery.Found(); len(found) != 2 {
+		t.Fatalf("expected two configs, got %v", found)
+	}
+}
+
+func TestDiscover_OutsideRepository(t *testing.T) {
+	dir := t.TempDir()
+
+	discovery, err := Discover(dir)
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	if discovery.Root != "" {
+		t.Fatalf("expected no root, got %s", discovery.Root)
+	}
+	if len(discovery.Candidates) != 1 || discovery.Candidates[0] != filepath.Join(dir, RepoConfigName) {
+		t.Fatalf("unexpected candidates: %v", discovery.Candidates)
+	}
+}
@@ -0,0 +1,569 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultIndent matches the indentation yaml.Marshal uses for new files.
+const defaultIndent = 4
+
+// Document is a config file edited in place. Set and Delete rewrite only the
+// lines of the entries they touch, so comments, key order and formatting
+// elsewhere in the file survive. Edits that cannot be expressed as a line
+// change (flow collections, sequences) fall back to re-encoding the
+// YAML node tree, which still keeps comments and key order. Files with
+// CRLF line endings are edited with LF and written back with CRLF.
+type Document struct {
+	payload []byte
+	eol     string
+}
+
+// newDocument wraps payload, remembering its line ending.
+func newDocument(payload []byte) *Document {
+	if bytes.Contains(payload, []byte("\r\n")) {
+		return &Document{payload: bytes.ReplaceAll(payload, []byte("\r\n"), []byte("\n")), eol: "\r\n"}
+	}
+	return &Document{payload: payload, eol: "\n"}
+}
+
+// ParseDocument wraps a config payload for editing. An empty payload starts
+// a new file at the current schema; a legacy flat layout is migrated first
+// because entries cannot be added to it in place.
+func ParseDocument(payload []byte) (*Document, error) {
+	if len(bytes.TrimSpace(payload)) == 0 {
+		return newDocument([]byte(fmt.Sprintf("schema_version: %d\n", CurrentSchemaVersion))), nil
+	}
+
+	version, err := DetectSchemaVersion(payload)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 {
+		migration, err := Migrate(payload)
+		if err != nil {
+			return nil, err
+		}
+		payload = migration.Payload
+	}
+
+	return newDocument(payload), nil
+}
+
+// ReadDocument reads a config file for editing. A missing file yields a new
+// document.
+func ReadDocument(path string) (*Document, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	return ParseDocument(payload)
+}
+
+// Bytes returns the current document contents.
+func (d *Document) Bytes() []byte {
+	if d.eol == "\r\n" {
+		return bytes.ReplaceAll(d.payload, []byte("\n"), []byte("\r\n"))
+	}
+	return d.payload
+}
+
+// Set stores a scalar value at path, creating missing sections.
+func (d *Document) Set(path []string, value any) error {
+	if len(path) == 0 {
+		return errors.New("set config: empty key")
+	}
+
+	if updated, ok := setLines(d.payload, path, value); ok && holds(updated, path, value) {
+		d.payload = updated
+		return nil
+	}
+
+	root, err := parseNode(d.payload)
+	if err != nil {
+		return err
+	}
+	valueNode := &yaml.Node{}
+	if err := valueNode.Encode(value); err != nil {
+		return err
+	}
+	if err := setNode(root, path, valueNode); err != nil {
+		return err
+	}
+	return d.encode(root)
+}
+
+// SetRecord stores a used_codenames entry. Records carrying more than a
+// codename need the current schema, so older files are stamped first.
+func (d *Document) SetRecord(version string, record Record) error {
+	if !record.IsPlain() {
+		current, err := DetectSchemaVersion(d.payload)
+		if err != nil {
+			return err
+		}
+		if current < CurrentSchemaVersion {
+			if err := d.Set([]string{"schema_version"}, CurrentSchemaVersion); err != nil {
+				return err
+			}
+		}
+	}
+	return d.Set([]string{"used_codenames", version}, record)
+}
+
+// Delete removes the entry at path. It reports whether the entry existed.
+func (d *Document) Delete(path []string) (bool, error) {
+	if len(path) == 0 {
+		return false, errors.New("delete config: empty key")
+	}
+
+	root, err := parseNode(d.payload)
+	if err != nil {
+		return false, err
+	}
+	parent, index := findEntry(root, path)
+	if parent == nil {
+		return false, nil
+	}
+
+	if updated, ok := deleteLines(d.payload, root, path); ok && !exists(updated, path) {
+		d.payload = updated
+		return true, nil
+	}
+
+	parent.Content = append(parent.Content[:index], parent.Content[index+2:]...)
+	return true, d.encode(root)
+}
+
+// foldLegacy moves top-level "<version>: <codename>" entries of the legacy
+// flat layout under used_codenames. Entries already in used_codenames win,
+// as they do in Decode.
+func (d *Document) foldLegacy() error {
+	root, err := parseNode(d.payload)
+	if err != nil {
+		return err
+	}
+	mapping := documentMapping(root)
+
+	var legacy, kept []*yaml.Node
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if _, known := knownKeys[key.Value]; !known && isVersionKey(key.Value) && value.Kind == yaml.ScalarNode {
+			legacy = append(legacy, key, value)
+			continue
+		}
+		kept = append(kept, key, value)
+	}
+	if len(legacy) == 0 {
+		return nil
+	}
+	mapping.Content = kept
+
+	index := keyIndex(mapping, "used_codenames")
+	if index < 0 {
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "used_codenames"}, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+		index = len(mapping.Content) - 2
+	}
+	records := mapping.Content[index+1]
+	if records.Kind == yaml.ScalarNode && records.Tag == "!!null" {
+		records = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", LineComment: records.LineComment}
+		mapping.Content[index+1] = records
+	}
+	if records.Kind != yaml.MappingNode {
+		return fmt.Errorf("%w: used_codenames must be a mapping", ErrParse)
+	}
+	records.Style &^= yaml.FlowStyle
+	for i := 0; i+1 < len(legacy); i += 2 {
+		if keyIndex(records, legacy[i].Value) < 0 {
+			records.Content = append(records.Content, legacy[i], legacy[i+1])
+		}
+	}
+	return d.encode(root)
+}
+
+// stampSchema sets schema_version to the current schema, adding it as the
+// first entry when the file has none.
+func (d *Document) stampSchema() error {
+	root, err := parseNode(d.payload)
+	if err != nil {
+		return err
+	}
+	mapping := documentMapping(root)
+	if keyIndex(mapping, "schema_version") >= 0 || len(mapping.Content) == 0 || mapping.Style&yaml.FlowStyle != 0 {
+		return d.Set([]string{"schema_version"}, CurrentSchemaVersion)
+	}
+
+	lines := splitLines(string(d.payload))
+	at := mapping.Content[0].Line - 1
+	stamp := fmt.Sprintf("schema_version: %d", CurrentSchemaVersion)
+	lines = append(lines[:at], append([]string{stamp}, lines[at:]...)...)
+	d.payload = joinLines(lines)
+	return nil
+}
+
+func (d *Document) encode(root *yaml.Node) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(detectIndent(d.payload))
+	if err := encoder.Encode(root); err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	d.payload = buf.Bytes()
+	return nil
+}
+
+func parseNode(payload []byte) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(payload, &root); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParse, err)
+	}
+	if root.Kind == 0 || (root.Kind == yaml.DocumentNode && len(root.Content) == 0) {
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if documentMapping(&root) == nil {
+		return nil, fmt.Errorf("%w: top level must be a mapping", ErrParse)
+	}
+	return &root, nil
+}
+
+// findEntry returns the mapping holding path and the index of its key node.
+func findEntry(root *yaml.Node, path []string) (*yaml.Node, int) {
+	mapping := documentMapping(root)
+	for i, segment := range path {
+		if mapping == nil || mapping.Kind != yaml.MappingNode {
+			return nil, 0
+		}
+		index := keyIndex(mapping, segment)
+		if index < 0 {
+			return nil, 0
+		}
+		if i == len(path)-1 {
+			return mapping, index
+		}
+		mapping = mapping.Content[index+1]
+	}
+	return nil, 0
+}
+
+func keyIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func setNode(root *yaml.Node, path []string, value *yaml.Node) error {
+	mapping := documentMapping(root)
+	for i, segment := range path {
+		index := keyIndex(mapping, segment)
+		if i == len(path)-1 {
+			if index >= 0 {
+				value.HeadComment = mapping.Content[index+1].HeadComment
+				value.LineComment = mapping.Content[index+1].LineComment
+				mapping.Content[index+1] = value
+			} else {
+				mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: segment}, value)
+			}
+			return nil
+		}
+
+		if index < 0 {
+			child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: segment}, child)
+			mapping = child
+			continue
+		}
+
+		child := mapping.Content[index+1]
+		if child.Kind == yaml.ScalarNode && child.Tag == "!!null" {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", LineComment: child.LineComment}
+			mapping.Content[index+1] = child
+		}
+		if child.Kind != yaml.MappingNode {
+			return fmt.Errorf("set config: %s is not a section", strings.Join(path[:i+1], "."))
+		}
+		child.Style &^= yaml.FlowStyle
+		mapping = child
+	}
+	return nil
+}
+
+// setLines applies Set as a line edit. It reports false when the change
+// cannot be made that way.
+func setLines(payload []byte, path []string, value any) ([]byte, bool) {
+	root, err := parseNode(payload)
+	if err != nil {
+		return nil, false
+	}
+
+	lines := splitLines(string(payload))
+	unit := detectIndent(payload)
+	mapping := documentMapping(root)
+	var parentKey *yaml.Node
+
+	for i, segment := range path {
+		index := keyIndex(mapping, segment)
+		if index < 0 {
+			if mapping.Style&yaml.FlowStyle != 0 && len(mapping.Content) > 0 {
+				return nil, false
+			}
+
+			indent, at := 0, len(lines)
+			switch {
+			case len(mapping.Content) > 0:
+				indent = mapping.Content[0].Column - 1
+				last := mapping.Content[len(mapping.Content)-2]
+				at = blockEnd(lines, last.Line, indent)
+			case parentKey != nil:
+				// Empty section ("key:", "key: {}" or "key: ~"): drop the
+				// placeholder value and add the entries below the key.
+				indent = parentKey.Column - 1 + unit
+				at = parentKey.Line
+				line, ok := replaceValue(lines[at-1], parentKey, mapping, "")
+				if !ok {
+					return nil, false
+				}
+				lines[at-1] = line
+			}
+
+			inserted, ok := entryLines(path[i:], value, indent, unit)
+			if !ok {
+				return nil, false
+			}
+			lines = append(lines[:at], append(inserted, lines[at:]...)...)
+			return joinLines(lines), true
+		}
+
+		key, node := mapping.Content[index], mapping.Content[index+1]
+		if i == len(path)-1 {
+			scalar, single := scalarText(value)
+			if single && node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 && node.Line == key.Line {
+				line, ok := replaceValue(lines[key.Line-1], key, node, " "+scalar)
+				if !ok {
+					return nil, false
+				}
+				lines[key.Line-1] = line
+				return joinLines(lines), true
+			}
+
+			// Replace the whole entry; comments inside it are lost, but
+			// the rest of the file is untouched.
+			replacement, ok := entryLines(path[i:], value, key.Column-1, unit)
+			if !ok {
+				return nil, false
+			}
+			end := blockEnd(lines, key.Line, key.Column-1)
+			lines = append(lines[:key.Line-1], append(replacement, lines[end:]...)...)
+			return joinLines(lines), true
+		}
+
+		switch {
+		case node.Kind == yaml.MappingNode && (node.Style&yaml.FlowStyle == 0 || len(node.Content) == 0):
+		case node.Kind == yaml.ScalarNode && node.Tag == "!!null" && node.Line == key.Line:
+			node = &yaml.Node{Kind: yaml.MappingNode, Line: node.Line, Column: node.Column, LineComment: node.LineComment}
+		default:
+			return nil, false
+		}
+		if node.Line != key.Line && len(node.Content) == 0 {
+			return nil, false
+		}
+		parentKey, mapping = key, node
+	}
+
+	return nil, false
+}
+
+// deleteLines applies Delete as a line edit.
+func deleteLines(payload []byte, root *yaml.Node, path []string) ([]byte, bool) {
+	mapping := documentMapping(root)
+	var parentKey, parentValue *yaml.Node
+	for _, segment := range path[:len(path)-1] {
+		index := keyIndex(mapping, segment)
+		parentKey, parentValue = mapping.Content[index], mapping.Content[index+1]
+		mapping = parentValue
+	}
+	if mapping.Style&yaml.FlowStyle != 0 {
+		return nil, false
+	}
+
+	lines := splitLines(string(payload))
+	index := keyIndex(mapping, path[len(path)-1])
+	key := mapping.Content[index]
+	end := blockEnd(lines, key.Line, key.Column-1)
+	lines = append(lines[:key.Line-1], lines[end:]...)
+
+	// Removing the last entry of a section leaves "key:", which reads as
+	// null; write an explicit empty mapping instead.
+	if parentKey != nil && len(mapping.Content) == 2 {
+		line, ok := replaceValue(lines[parentKey.Line-1], parentKey, parentValue, " {}")
+		if !ok {
+			return nil, false
+		}
+		lines[parentKey.Line-1] = line
+	}
+
+	return joinLines(lines), true
+}
+
+// replaceValue swaps the value text of key's line for text, keeping any
+// trailing comment. A value that starts on a later line (a block section)
+// is treated as empty, so text is added after the colon.
+func replaceValue(line string, key, node *yaml.Node, text string) (string, bool) {
+	runes := []rune(line)
+	start := len([]rune(stripComment(line, key, node)))
+	if node.Line == key.Line {
+		start = node.Column - 1
+	}
+	if start < 0 || start > len(runes) {
+		return "", false
+	}
+
+	head := strings.TrimRight(string(runes[:start]), " \t")
+	if !strings.HasSuffix(head, ":") {
+		return "", false
+	}
+	rest := string(runes[start:])
+	tail := rest[len(stripComment(rest, key, node)):]
+	return head + text + tail, true
+}
+
+// stripComment removes the trailing comment recorded on key or node.
+func stripComment(text string, key, node *yaml.Node) string {
+	for _, comment := range []string{node.LineComment, key.LineComment} {
+		if comment == "" {
+			continue
+		}
+		if index := strings.LastIndex(text, comment); index >= 0 {
+			return strings.TrimRight(text[:index], " \t")
+		}
+	}
+	return strings.TrimRight(text, " \t")
+}
+
+// blockEnd returns the number of lines up to and including the last content
+// line of the entry whose key starts on line at the given indentation.
+func blockEnd(lines []string, line, indent int) int {
+	end := line
+	for i := line; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		leading := len(lines[i]) - len(strings.TrimLeft(lines[i], " "))
+		if leading < indent || (leading == indent && !strings.HasPrefix(trimmed, "- ") && trimmed != "-") {
+			break
+		}
+		end = i + 1
+	}
+	return end
+}
+
+// entryLines renders value nested under path as block YAML indented by
+// indent spaces.
+func entryLines(path []string, value any, indent, unit int) ([]string, bool) {
+	nested := value
+	for i := len(path) - 1; i >= 0; i-- {
+		nested = map[string]any{path[i]: nested}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(unit)
+	if err := encoder.Encode(nested); err != nil {
+		return nil, false
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, false
+	}
+
+	lines := splitLines(buf.String())
+	prefix := strings.Repeat(" ", indent)
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return lines, true
+}
+
+// scalarText renders value as a single-line YAML scalar, quoting it when
+// needed (for example "1.0" or "true" as strings).
+func scalarText(value any) (string, bool) {
+	encoded, err := yaml.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	text := strings.TrimSuffix(string(encoded), "\n")
+	if text == "" || strings.Contains(text, "\n") {
+		return "", false
+	}
+	return text, true
+}
+
+// detectIndent returns the indentation of the first nested mapping, or the
+// yaml.Marshal default.
+func detectIndent(payload []byte) int {
+	root, err := parseNode(payload)
+	if err != nil {
+		return defaultIndent
+	}
+	mapping := documentMapping(root)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if value.Kind == yaml.MappingNode && value.Style&yaml.FlowStyle == 0 && len(value.Content) > 0 {
+			if indent := value.Content[0].Column - key.Column; indent > 0 {
+				return indent
+			}
+		}
+	}
+	return defaultIndent
+}
+
+// holds reports whether payload parses and stores value at path.
+func holds(payload []byte, path []string, value any) bool {
+	root, err := parseNode(payload)
+	if err != nil {
+		return false
+	}
+	mapping, index := findEntry(root, path)
+	if mapping == nil {
+		return false
+	}
+
+	want := reflect.New(reflect.TypeOf(value))
+	if err := mapping.Content[index+1].Decode(want.Interface()); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(want.Elem().Interface(), value)
+}
+
+func exists(payload []byte, path []string) bool {
+	root, err := parseNode(payload)
+	if err != nil {
+		return true
+	}
+	mapping, _ := findEntry(root, path)
+	return mapping != nil
+}
+
+func joinLines(lines []string) []byte {
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
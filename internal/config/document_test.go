@@ -0,0 +1,214 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDocument_Golden(t *testing.T) {
+	cases := []struct {
+		name string
+		edit func(doc *Document) error
+	}{
+		{
+			name: "record",
+			edit: func(doc *Document) error {
+				if err := doc.Set([]string{"default_theme"}, "crayola_colors"); err != nil {
+					return err
+				}
+				return doc.Set([]string{"used_codenames", UnreleasedKey}, "Asparagus")
+			},
+		},
+		{
+			name: "release",
+			edit: func(doc *Document) error {
+				if err := doc.Set([]string{"used_codenames", "0.1.1-beta.1"}, "Aqua"); err != nil {
+					return err
+				}
+				return doc.Set([]string{"used_codenames", "0.2.0"}, "Asparagus")
+			},
+		},
+		{
+			name: "empty_section",
+			edit: func(doc *Document) error {
+				return doc.Set([]string{"used_codenames", "1.0"}, "Heron")
+			},
+		},
+		{
+			name: "new_section",
+			edit: func(doc *Document) error {
+				if err := doc.Set([]string{"api", "enabled"}, true); err != nil {
+					return err
+				}
+				return doc.Set([]string{"default_format"}, "yes")
+			},
+		},
+		{
+			name: "rich_record",
+			edit: func(doc *Document) error {
+				if _, err := doc.Delete([]string{"used_codenames", UnreleasedKey}); err != nil {
+					return err
+				}
+				return doc.SetRecord("0.2.0", Record{
+					Codename:   "Aquamarine",
+					Theme:      "crayola_colors",
+					Seed:       42,
+					RecordedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+					Notes:      "promoted from unreleased",
+				})
+			},
+		},
+		{
+			name: "remove_last",
+			edit: func(doc *Document) error {
+				removed, err := doc.Delete([]string{"used_codenames", UnreleasedKey})
+				if err == nil && !removed {
+					t.Errorf("expected entry to be removed")
+				}
+				return err
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			inputPath := filepath.Join("testdata", "document", tc.name+".yaml")
+			input, err := os.ReadFile(inputPath)
+			if err != nil {
+				t.Fatalf("read input: %v", err)
+			}
+
+			doc, err := ParseDocument(input)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if err := tc.edit(doc); err != nil {
+				t.Fatalf("edit: %v", err)
+			}
+
+			golden, err := os.ReadFile(filepath.Join("testdata", "document", tc.name+".diff"))
+			if err != nil {
+				t.Fatalf("read golden file: %v", err)
+			}
+
+			diff := Diff(tc.name+".yaml", tc.name+".yaml", input, doc.Bytes())
+			if diff != string(golden) {
+				t.Fatalf("diff mismatch\nexpected:\n%s\nactual:\n%s", golden, diff)
+			}
+
+			if _, _, err := Decode(doc.Bytes()); err != nil {
+				t.Fatalf("edited document does not parse: %v", err)
+			}
+		})
+	}
+}
+
+func TestDocument_NewFile(t *testing.T) {
+	doc, err := ParseDocument(nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.Set([]string{"used_codenames", UnreleasedKey}, "Heron"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	expected := "schema_version: 2\nused_codenames:\n    unreleased: Heron\n"
+	if string(doc.Bytes()) != expected {
+		t.Fatalf("unexpected document:\n%s", doc.Bytes())
+	}
+}
+
+func TestDocument_MigratesLegacyLayout(t *testing.T) {
+	doc, err := ParseDocument([]byte("0.1.0: Apricot\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.Set([]string{"used_codenames", "0.2.0"}, "Aquamarine"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	cfg, warnings, err := Decode(doc.Bytes())
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+	if cfg.UsedCodenames["0.1.0"].Codename != "Apricot" || cfg.UsedCodenames["0.2.0"].Codename != "Aquamarine" {
+		t.Fatalf("unexpected codenames: %v", cfg.UsedCodenames)
+	}
+}
+
+func TestDocument_FallsBackForFlowMappings(t *testing.T) {
+	doc, err := ParseDocument([]byte("# keep me\nused_codenames: {0.1.0: Apricot}\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.Set([]string{"used_codenames", "0.2.0"}, "Aquamarine"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	payload := string(doc.Bytes())
+	if !strings.Contains(payload, "# keep me") {
+		t.Fatalf("expected comment to survive, got:\n%s", payload)
+	}
+	cfg, _, err := Decode(doc.Bytes())
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if cfg.UsedCodenames["0.1.0"].Codename != "Apricot" || cfg.UsedCodenames["0.2.0"].Codename != "Aquamarine" {
+		t.Fatalf("unexpected codenames: %v", cfg.UsedCodenames)
+	}
+}
+
+func TestDocument_KeepsCRLF(t *testing.T) {
+	input := "# releases\r\nschema_version: 2\r\nused_codenames:\r\n  0.1.0: Almond # first\r\n"
+	doc, err := ParseDocument([]byte(input))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.Set([]string{"used_codenames", "0.2.0"}, "Apricot"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := doc.SetRecord("0.3.0", Record{Codename: "Aquamarine", Notes: "third"}); err != nil {
+		t.Fatalf("set record: %v", err)
+	}
+	if err := doc.Set([]string{"api", "enabled"}, true); err != nil {
+		t.Fatalf("set section: %v", err)
+	}
+
+	payload := string(doc.Bytes())
+	if !strings.HasPrefix(payload, input) {
+		t.Fatalf("expected the original lines to be kept, got %q", payload)
+	}
+	if strings.Count(payload, "\n") != strings.Count(payload, "\r\n") {
+		t.Fatalf("expected only CRLF line endings, got %q", payload)
+	}
+
+	migration, err := Migrate([]byte("default_theme: birds\r\n"))
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if string(migration.Payload) != "schema_version: 2\r\ndefault_theme: birds\r\n" {
+		t.Fatalf("expected migration to keep CRLF, got %q", migration.Payload)
+	}
+}
+
+func TestDocument_DeleteMissing(t *testing.T) {
+	doc, err := ParseDocument([]byte("default_theme: birds\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	removed, err := doc.Delete([]string{"used_codenames", "0.1.0"})
+	if err != nil || removed {
+		t.Fatalf("expected no-op delete, got removed=%v err=%v", removed, err)
+	}
+}
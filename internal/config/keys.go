@@ -0,0 +1,317 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrKeyNotSet is returned when a key path points at a map entry that does
+// not exist.
+var ErrKeyNotSet = errors.New("key not set")
+
+// ParseKeyPath splits a dotted key path into segments. Dots can be escaped
+// with a backslash (used_codenames.0\.1\.0) or protected by quoting a
+// segment (used_codenames."0.1.0").
+func ParseKeyPath(path string) ([]string, error) {
+	var segments []string
+	var current strings.Builder
+	var quote rune
+	escaped := false
+	quoted := false
+
+	flush := func() error {
+		if current.Len() == 0 && !quoted {
+			return fmt.Errorf("invalid key %q: empty segment", path)
+		}
+		segments = append(segments, current.String())
+		current.Reset()
+		quoted = false
+		return nil
+	}
+
+	for _, r := range path {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			quoted = true
+		case r == '.':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("invalid key %q: trailing escape", path)
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("invalid key %q: unterminated quote", path)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return segments, nil
+}
+
+// GetKey returns the value at a dotted key path. Sections are returned as
+// their Go values (structs or maps) so callers can render them.
+func GetKey(cfg Config, path string) (any, error) {
+	ref, err := lookupKey(reflect.ValueOf(&cfg).Elem(), path)
+	if err != nil {
+		return nil, err
+	}
+	if ref.isMapEntry {
+		value := ref.value.MapIndex(reflect.ValueOf(ref.mapKey))
+		if !value.IsValid() {
+			return nil, fmt.Errorf("%s: %w", path, ErrKeyNotSet)
+		}
+		if ref.field != "" {
+			value, _ = fieldByTag(value, ref.field)
+		}
+		return value.Interface(), nil
+	}
+	return ref.value.Interface(), nil
+}
+
+// FormatValue renders a value returned by GetKey: scalars as plain text and
+// sections as YAML.
+func FormatValue(value any) (string, error) {
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	case bool, int, int64:
+		return fmt.Sprint(typed), nil
+	}
+	payload, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(payload), "\n"), nil
+}
+
+// SetKey parses raw according to the type of the field at path and stores it.
+func SetKey(cfg *Config, path, raw string) error {
+	ref, err := lookupKey(reflect.ValueOf(cfg).Elem(), path)
+	if err != nil {
+		return err
+	}
+
+	if ref.isMapEntry {
+		if ref.value.IsNil() {
+			ref.value.Set(reflect.MakeMap(ref.value.Type()))
+		}
+		elem := reflect.New(ref.value.Type().Elem()).Elem()
+		existing := ref.value.MapIndex(reflect.ValueOf(ref.mapKey))
+		if existing.IsValid() {
+			elem.Set(existing)
+		}
+		target := elem
+		if ref.field != "" {
+			if !existing.IsValid() && ref.field != "codename" {
+				return fmt.Errorf("%s: %w; set its codename first", strings.TrimSuffix(path, "."+ref.field), ErrKeyNotSet)
+			}
+			target, _ = fieldByTag(elem, ref.field)
+		}
+		if err := setScalar(target, path, raw); err != nil {
+			return err
+		}
+		ref.value.SetMapIndex(reflect.ValueOf(ref.mapKey), elem)
+		return nil
+	}
+
+	return setScalar(ref.value, path, raw)
+}
+
+// UnsetKey removes a map entry or resets a field to its zero value.
+func UnsetKey(cfg *Config, path string) error {
+	ref, err := lookupKey(reflect.ValueOf(cfg).Elem(), path)
+	if err != nil {
+		return err
+	}
+
+	if ref.isMapEntry {
+		key := reflect.ValueOf(ref.mapKey)
+		if ref.value.IsNil() || !ref.value.MapIndex(key).IsValid() {
+			return fmt.Errorf("%s: %w", path, ErrKeyNotSet)
+		}
+		if ref.field == "" {
+			ref.value.SetMapIndex(key, reflect.Value{})
+			return nil
+		}
+		if ref.field == "codename" {
+			return fmt.Errorf("%s is required; unset %s instead", path, strings.TrimSuffix(path, ".codename"))
+		}
+		elem := reflect.New(ref.value.Type().Elem()).Elem()
+		elem.Set(ref.value.MapIndex(key))
+		field, _ := fieldByTag(elem, ref.field)
+		field.Set(reflect.Zero(field.Type()))
+		ref.value.SetMapIndex(key, elem)
+		return nil
+	}
+
+	ref.value.Set(reflect.Zero(ref.value.Type()))
+	return nil
+}
+
+type keyRef struct {
+	value      reflect.Value
+	mapKey     string
+	isMapEntry bool
+	// field is the record field below a map entry, or "".
+	field string
+}
+
+// KeySegments resolves a dotted key path against the config schema. Map
+// entry keys keep their dots, so used_codenames.0.1.0 yields
+// [used_codenames 0.1.0]. A trailing record field is split off:
+// used_codenames.0.1.0.notes yields [used_codenames 0.1.0 notes].
+func KeySegments(path string) ([]string, error) {
+	segments, err := ParseKeyPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := reflect.ValueOf(Config{})
+	for i, segment := range segments {
+		if current.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("invalid key %q: %s is not a section", path, strings.Join(segments[:i], "."))
+		}
+
+		field, ok := fieldByTag(current, segment)
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", strings.Join(segments[:i+1], "."))
+		}
+
+		if field.Kind() == reflect.Map && i+1 < len(segments) {
+			// Map keys may contain dots (SemVer versions), so the remainder
+			// of the path is the entry key, followed by a field name when
+			// the entries are records.
+			rest := segments[i+1:]
+			entry := reflect.New(field.Type().Elem()).Elem()
+			if entry.Kind() != reflect.Struct {
+				return append(segments[:i+1:i+1], strings.Join(rest, ".")), nil
+			}
+			key, last := strings.Join(rest, "."), ""
+			if len(rest) > 1 {
+				if _, ok := fieldByTag(entry, rest[len(rest)-1]); ok {
+					key, last = strings.Join(rest[:len(rest)-1], "."), rest[len(rest)-1]
+				}
+			}
+			if key != UnreleasedKey && !isVersionKey(key) {
+				return nil, fmt.Errorf("invalid key %q: %q is not a release version followed by an optional record field (%s)", path, key, strings.Join(fieldTags(entry), ", "))
+			}
+			if last == "" {
+				return append(segments[:i+1:i+1], key), nil
+			}
+			return append(segments[:i+1:i+1], key, last), nil
+		}
+		current = field
+	}
+
+	return segments, nil
+}
+
+func lookupKey(root reflect.Value, path string) (keyRef, error) {
+	segments, err := KeySegments(path)
+	if err != nil {
+		return keyRef{}, err
+	}
+
+	current := root
+	for i, segment := range segments {
+		field, _ := fieldByTag(current, segment)
+		if field.Kind() == reflect.Map && i+1 < len(segments) {
+			ref := keyRef{value: field, mapKey: segments[i+1], isMapEntry: true}
+			if i+2 < len(segments) {
+				ref.field = segments[i+2]
+			}
+			return ref, nil
+		}
+		current = field
+	}
+
+	return keyRef{value: current}, nil
+}
+
+func fieldByTag(value reflect.Value, name string) (reflect.Value, bool) {
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		tag := strings.Split(typ.Field(i).Tag.Get("yaml"), ",")[0]
+		if tag == name {
+			return value.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func fieldTags(value reflect.Value) []string {
+	typ := value.Type()
+	tags := make([]string, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		tags = append(tags, strings.Split(typ.Field(i).Tag.Get("yaml"), ",")[0])
+	}
+	return tags
+}
+
+func setScalar(field reflect.Value, path, raw string) error {
+	if _, ok := field.Interface().(time.Time); ok {
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%s expects an RFC 3339 time, got %q", path, raw)
+		}
+		field.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%s expects a boolean, got %q", path, raw)
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s expects an integer, got %q", path, raw)
+		}
+		field.SetInt(parsed)
+	case reflect.Struct, reflect.Map:
+		if record, ok := field.Addr().Interface().(*Record); ok {
+			// Setting a release entry changes its codename and keeps the
+			// rest of the record.
+			record.Codename = raw
+			return nil
+		}
+		return fmt.Errorf("%s is a section; set one of its keys instead", path)
+	default:
+		return fmt.Errorf("%s has unsupported type %s", path, field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseKeyPath(t *testing.T) {
+	cases := map[string][]string{
+		"api.endpoint":                   {"api", "endpoint"},
+		`used_codenames.0\.1\.0-beta\.2`: {"used_codenames", "0.1.0-beta.2"},
+		`used_codenames."0.1.0"`:         {"used_codenames", "0.1.0"},
+		`used_codenames.'1.0.0'`:         {"used_codenames", "1.0.0"},
+	}
+	for input, want := range cases {
+		got, err := ParseKeyPath(input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", input, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: expected %v, got %v", input, want, got)
+		}
+	}
+
+	for _, invalid := range []string{"", "api..endpoint", `api\`, `used_codenames."0.1`} {
+		if _, err := ParseKeyPath(invalid); err == nil {
+			t.Fatalf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestSetGetUnsetKey(t *testing.T) {
+	cfg := Default()
+
+	if err := SetKey(&cfg, "default_theme", "birds"); err != nil {
+		t.Fatalf("set default_theme: %v", err)
+	}
+	if err := SetKey(&cfg, "api.enabled", "true"); err != nil {
+		t.Fatalf("set api.enabled: %v", err)
+	}
+	if err := SetKey(&cfg, "used_codenames.0.1.0-beta.2", "Apricot"); err != nil {
+		t.Fatalf("set used codename: %v", err)
+	}
+
+	if cfg.DefaultTheme != "birds" || !cfg.API.Enabled || cfg.UsedCodenames["0.1.0-beta.2"].Codename != "Apricot" {
+		t.Fatalf("unexpected config after set: %+v", cfg)
+	}
+
+	value, err := GetKey(cfg, `used_codenames."0.1.0-beta.2"`)
+	if err != nil || value != (Record{Codename: "Apricot"}) {
+		t.Fatalf("expected Apricot, got %v (%v)", value, err)
+	}
+
+	if err := UnsetKey(&cfg, "used_codenames.0.1.0-beta.2"); err != nil {
+		t.Fatalf("unset: %v", err)
+	}
+	if _, err := GetKey(cfg, "used_codenames.0.1.0-beta.2"); !errors.Is(err, ErrKeyNotSet) {
+		t.Fatalf("expected ErrKeyNotSet, got %v", err)
+	}
+}
+
+func TestRecordFieldKeys(t *testing.T) {
+	segments, err := KeySegments(`used_codenames.0.1.0-beta.2.notes`)
+	if err != nil || !reflect.DeepEqual(segments, []string{"used_codenames", "0.1.0-beta.2", "notes"}) {
+		t.Fatalf("unexpected segments %v (%v)", segments, err)
+	}
+	if segments, err := KeySegments(`used_codenames."1.0.0".recorded_at`); err != nil || !reflect.DeepEqual(segments, []string{"used_codenames", "1.0.0", "recorded_at"}) {
+		t.Fatalf("unexpected quoted segments %v (%v)", segments, err)
+	}
+	if _, err := KeySegments("used_codenames.0.1.0.nots"); err == nil || !strings.Contains(err.Error(), "notes") {
+		t.Fatalf("expected unknown record field error, got %v", err)
+	}
+
+	cfg := Default()
+	if err := SetKey(&cfg, "used_codenames.0.2.0.notes", "early"); !errors.Is(err, ErrKeyNotSet) {
+		t.Fatalf("expected a missing record to be refused, got %v", err)
+	}
+	if err := SetKey(&cfg, "used_codenames.0.2.0", "Apricot"); err != nil {
+		t.Fatalf("set codename: %v", err)
+	}
+	for key, value := range map[string]string{
+		"used_codenames.0.2.0.notes":       "second beta",
+		"used_codenames.0.2.0.seed":        "42",
+		"used_codenames.0.2.0.recorded_at": "2026-03-01T12:00:00Z",
+	} {
+		if err := SetKey(&cfg, key, value); err != nil {
+			t.Fatalf("set %s: %v", key, err)
+		}
+	}
+	want := Record{Codename: "Apricot", Notes: "second beta", Seed: 42, RecordedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	if cfg.UsedCodenames["0.2.0"] != want {
+		t.Fatalf("unexpected record: %+v", cfg.UsedCodenames["0.2.0"])
+	}
+	if value, err := GetKey(cfg, "used_codenames.0.2.0.notes"); err != nil || value != "second beta" {
+		t.Fatalf("expected notes, got %v (%v)", value, err)
+	}
+	if err := SetKey(&cfg, "used_codenames.0.2.0.seed", "soon"); err == nil {
+		t.Fatalf("expected integer type error")
+	}
+
+	if err := UnsetKey(&cfg, "used_codenames.0.2.0.notes"); err != nil {
+		t.Fatalf("unset notes: %v", err)
+	}
+	if cfg.UsedCodenames["0.2.0"].Notes != "" || cfg.UsedCodenames["0.2.0"].Codename != "Apricot" {
+		t.Fatalf("expected only notes to be removed, got %+v", cfg.UsedCodenames["0.2.0"])
+	}
+	if err := UnsetKey(&cfg, "used_codenames.0.2.0.codename"); err == nil {
+		t.Fatalf("expected unsetting the codename to be refused")
+	}
+}
+
+func TestSetKey_TypeChecks(t *testing.T) {
+	cfg := Default()
+
+	if err := SetKey(&cfg, "api.enabled", "sometimes"); err == nil {
+		t.Fatalf("expected boolean type error")
+	}
+	if err := SetKey(&cfg, "schema_version", "one"); err == nil {
+		t.Fatalf("expected integer type error")
+	}
+	if err := SetKey(&cfg, "api", "x"); err == nil {
+		t.Fatalf("expected error when setting a section")
+	}
+	if err := SetKey(&cfg, "default_thme", "birds"); err == nil {
+		t.Fatalf("expected unknown key error")
+	}
+	if err := SetKey(&cfg, "default_theme.extra", "birds"); err == nil {
+		t.Fatalf("expected error when descending into a scalar")
+	}
+}
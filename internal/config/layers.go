@@ -0,0 +1,206 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Layer names, in increasing order of precedence.
+const (
+	LayerDefault = "default"
+	LayerUser    = "user"
+	LayerRepo    = "repo"
+	LayerEnv     = "env"
+	LayerFlag    = "flag"
+)
+
+// SettingKeys lists the scalar settings that are merged across layers.
+// Release history (used_codenames) is repository state and is never layered.
+var SettingKeys = []string{
+	"default_theme",
+	"default_format",
+	"api.enabled",
+	"api.endpoint",
+	"api.cache_dir",
+	"api.cache_ttl",
+}
+
+// Layer is one source of settings, keyed by dotted setting name. Only keys
+// that the source actually sets are present in Values.
+type Layer struct {
+	Name   string
+	Path   string
+	Values map[string]string
+}
+
+// Setting is the effective value of a key and the layer it came from.
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Path   string `json:"path,omitempty"`
+}
+
+// Effective is the result of merging layers.
+type Effective struct {
+	Settings []Setting `json:"settings"`
+}
+
+// Get returns the effective value for key, or an empty string if unknown.
+func (e Effective) Get(key string) string {
+	setting, _ := e.Lookup(key)
+	return setting.Value
+}
+
+// Lookup returns the effective setting for key, including the layer it
+// came from.
+func (e Effective) Lookup(key string) (Setting, bool) {
+	for _, setting := range e.Settings {
+		if setting.Key == key {
+			return setting, true
+		}
+	}
+	return Setting{}, false
+}
+
+// EnvName returns the environment variable that overrides a setting key,
+// for example TAGTASTIC_DEFAULT_THEME for default_theme.
+func EnvName(key string) string {
+	return "TAGTASTIC_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// DefaultLayer returns the built-in defaults as a layer.
+func DefaultLayer() Layer {
+	cfg := Default()
+	return Layer{
+		Name: LayerDefault,
+		Values: map[string]string{
+			"default_theme":  cfg.DefaultTheme,
+			"default_format": cfg.DefaultFormat,
+			"api.enabled":    strconv.FormatBool(cfg.API.Enabled),
+			"api.endpoint":   cfg.API.Endpoint,
+			"api.cache_dir":  cfg.API.CacheDir,
+			"api.cache_ttl":  cfg.API.CacheTTL,
+		},
+	}
+}
+
+// FileLayer reads the settings present in a config file. A missing file
+// yields an empty layer, and empty values are treated as unset.
+func FileLayer(name, path string) (Layer, error) {
+	layer := Layer{Name: name, Path: path, Values: map[string]string{}}
+
+	resolved, err := ResolvePath(path)
+	if err != nil {
+		return layer, err
+	}
+	layer.Path = resolved
+
+	payload, err := os.ReadFile(resolved)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return layer, nil
+		}
+		return layer, fmt.Errorf("read config: %w", err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(payload, &raw); err != nil {
+		return layer, fmt.Errorf("%w %s: %w", ErrParse, resolved, err)
+	}
+
+	flat := map[string]string{}
+	flatten("", raw, flat)
+	for _, key := range SettingKeys {
+		if value, ok := flat[key]; ok && value != "" {
+			layer.Values[key] = value
+		}
+	}
+
+	return layer, nil
+}
+
+// EnvLayer reads TAGTASTIC_* overrides using lookup (usually os.LookupEnv).
+func EnvLayer(lookup func(string) (string, bool)) Layer {
+	layer := Layer{Name: LayerEnv, Values: map[string]string{}}
+	for _, key := range SettingKeys {
+		if value, ok := lookup(EnvName(key)); ok && strings.TrimSpace(value) != "" {
+			layer.Values[key] = strings.TrimSpace(value)
+		}
+	}
+	return layer
+}
+
+// FlagLayer wraps command-line values; empty values are treated as unset.
+func FlagLayer(values map[string]string) Layer {
+	layer := Layer{Name: LayerFlag, Values: map[string]string{}}
+	for key, value := range values {
+		if strings.TrimSpace(value) != "" {
+			layer.Values[key] = value
+		}
+	}
+	return layer
+}
+
+// Merge applies layers in order; later layers override earlier ones.
+func Merge(layers ...Layer) Effective {
+	settings := make(map[string]Setting, len(SettingKeys))
+	for _, layer := range layers {
+		for key, value := range layer.Values {
+			settings[key] = Setting{Key: key, Value: value, Source: layer.Name, Path: layer.Path}
+		}
+	}
+
+	order := make(map[string]int, len(SettingKeys))
+	for i, key := range SettingKeys {
+		order[key] = i
+	}
+
+	result := Effective{Settings: make([]Setting, 0, len(settings))}
+	for _, setting := range settings {
+		result.Settings = append(result.Settings, setting)
+	}
+	sort.Slice(result.Settings, func(i, j int) bool {
+		oi, iKnown := order[result.Settings[i].Key]
+		oj, jKnown := order[result.Settings[j].Key]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		if oi != oj {
+			return oi < oj
+		}
+		return result.Settings[i].Key < result.Settings[j].Key
+	})
+
+	return result
+}
+
+func flatten(prefix string, value any, out map[string]string) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, child := range typed {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			flatten(name, child, out)
+		}
+	case nil, []any:
+		// Nulls and lists are not layered settings.
+	default:
+		if prefix != "" {
+			out[prefix] = fmt.Sprint(typed)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMerge_Precedence(t *testing.T) {
+	tmp := t.TempDir()
+	userPath := filepath.Join(tmp, "user.yaml")
+	repoPath := filepath.Join(tmp, "repo.yaml")
+
+	if err := os.WriteFile(userPath, []byte("default_theme: birds\ndefault_format: json\n"), 0o600); err != nil {
+		t.Fatalf("write user config: %v", err)
+	}
+	if err := os.WriteFile(repoPath, []byte("default_theme: cities\napi:\n  enabled: true\n"), 0o600); err != nil {
+		t.Fatalf("write repo config: %v", err)
+	}
+
+	user, err := FileLayer(LayerUser, userPath)
+	if err != nil {
+		t.Fatalf("user layer: %v", err)
+	}
+	repo, err := FileLayer(LayerRepo, repoPath)
+	if err != nil {
+		t.Fatalf("repo layer: %v", err)
+	}
+	env := EnvLayer(func(key string) (string, bool) {
+		if key == "TAGTASTIC_DEFAULT_FORMAT" {
+			return "shell", true
+		}
+		return "", false
+	})
+	flags := FlagLayer(map[string]string{"default_theme": "landmarks", "default_format": ""})
+
+	effective := Merge(DefaultLayer(), user, repo, env, flags)
+
+	cases := map[string][2]string{
+		"default_theme":  {"landmarks", LayerFlag},
+		"default_format": {"shell", LayerEnv},
+		"api.enabled":    {"true", LayerRepo},
+		"api.endpoint":   {"", LayerDefault},
+	}
+	for key, want := range cases {
+		var found bool
+		for _, setting := range effective.Settings {
+			if setting.Key != key {
+				continue
+			}
+			found = true
+			if setting.Value != want[0] || setting.Source != want[1] {
+				t.Fatalf("%s: expected %q from %s, got %q from %s", key, want[0], want[1], setting.Value, setting.Source)
+			}
+		}
+		if !found {
+			t.Fatalf("expected setting %s", key)
+		}
+	}
+}
+
+func TestFileLayer_MissingFile(t *testing.T) {
+	layer, err := FileLayer(LayerRepo, filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("expected missing file to be ignored, got %v", err)
+	}
+	if len(layer.Values) != 0 {
+		t.Fatalf("expected empty layer, got %v", layer.Values)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	if got := EnvName("api.cache_ttl"); got != "TAGTASTIC_API_CACHE_TTL" {
+		t.Fatalf("unexpected env name: %q", got)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"fmt"
+	"path"
+)
+
+// Policy holds the naming rules enforced by generate and validate --policy.
+// Zero values disable a rule.
+type Policy struct {
+	// LetterDistance is the minimum distance in the alphabet between the
+	// first letters of consecutive codenames; 1 forbids repeating a letter.
+	LetterDistance int `yaml:"letter_distance,omitempty" json:"letter_distance,omitempty"`
+	// Length limits codename length for matching versions.
+	Length []LengthRule `yaml:"length,omitempty" json:"length,omitempty"`
+	// Themes restricts the themes matching versions may use.
+	Themes []ThemeRule `yaml:"themes,omitempty" json:"themes,omitempty"`
+	// ReuseWindow forbids reusing a codename from the last N releases.
+	ReuseWindow int `yaml:"reuse_window,omitempty" json:"reuse_window,omitempty"`
+}
+
+// LengthRule bounds the number of characters in a codename. Versions is a
+// glob such as "*.0.0"; an empty pattern matches every version.
+type LengthRule struct {
+	Versions string `yaml:"versions,omitempty" json:"versions,omitempty"`
+	Min      int    `yaml:"min,omitempty" json:"min,omitempty"`
+	Max      int    `yaml:"max,omitempty" json:"max,omitempty"`
+}
+
+// ThemeRule limits matching versions to the Allow themes, and the Reserve
+// themes to matching versions.
+type ThemeRule struct {
+	Versions string   `yaml:"versions,omitempty" json:"versions,omitempty"`
+	Allow    []string `yaml:"allow,omitempty" json:"allow,omitempty"`
+	Reserve  []string `yaml:"reserve,omitempty" json:"reserve,omitempty"`
+}
+
+// IsZero reports whether no rule is configured.
+func (p Policy) IsZero() bool {
+	return p.LetterDistance == 0 && len(p.Length) == 0 && len(p.Themes) == 0 && p.ReuseWindow == 0
+}
+
+func policyFindings(policy Policy, opts ValidateOptions) []Finding {
+	var findings []Finding
+	add := func(severity, key, format string, args ...any) {
+		findings = append(findings, Finding{Severity: severity, Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+	checkPattern := func(key, pattern string) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			add(SeverityError, key, "invalid version pattern %q", pattern)
+		}
+	}
+	checkTheme := func(key, theme string) {
+		if opts.ThemeExists != nil && !opts.ThemeExists(theme) {
+			add(SeverityError, key, "theme %q does not exist", theme)
+		}
+	}
+
+	if policy.LetterDistance < 0 || policy.LetterDistance > 25 {
+		add(SeverityError, "policy.letter_distance", "must be between 0 and 25, got %d", policy.LetterDistance)
+	}
+	if policy.ReuseWindow < 0 {
+		add(SeverityError, "policy.reuse_window", "must not be negative, got %d", policy.ReuseWindow)
+	}
+
+	for i, rule := range policy.Length {
+		key := fmt.Sprintf("policy.length[%d]", i)
+		checkPattern(key+".versions", rule.Versions)
+		switch {
+		case rule.Min < 0 || rule.Max < 0:
+			add(SeverityError, key, "min and max must not be negative")
+		case rule.Min == 0 && rule.Max == 0:
+			add(SeverityWarning, key, "sets neither min nor max")
+		case rule.Max > 0 && rule.Min > rule.Max:
+			add(SeverityError, key, "min %d is greater than max %d", rule.Min, rule.Max)
+		}
+	}
+
+	for i, rule := range policy.Themes {
+		key := fmt.Sprintf("policy.themes[%d]", i)
+		checkPattern(key+".versions", rule.Versions)
+		if len(rule.Allow) == 0 && len(rule.Reserve) == 0 {
+			add(SeverityWarning, key, "sets neither allow nor reserve")
+		}
+		for j, theme := range rule.Allow {
+			checkTheme(fmt.Sprintf("%s.allow[%d]", key, j), theme)
+		}
+		for j, theme := range rule.Reserve {
+			checkTheme(fmt.Sprintf("%s.reserve[%d]", key, j), theme)
+		}
+	}
+
+	return findings
+}
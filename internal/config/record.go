@@ -0,0 +1,95 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrRecorded is returned when a release version already has a record.
+var ErrRecorded = errors.New("already recorded")
+
+// Record is a used_codenames entry: the codename plus the audit details
+// captured when it was recorded. Entries written before records existed are
+// plain codename strings; they decode into a Record with only Codename set,
+// and such records are written back as plain strings.
+type Record struct {
+	Codename   string    `yaml:"codename" json:"codename"`
+	Theme      string    `yaml:"theme,omitempty" json:"theme,omitempty"`
+	Seed       int64     `yaml:"seed,omitempty" json:"seed,omitempty"`
+	RecordedAt time.Time `yaml:"recorded_at,omitempty" json:"recorded_at,omitzero"`
+	Commit     string    `yaml:"commit,omitempty" json:"commit,omitempty"`
+	RecordedBy string    `yaml:"recorded_by,omitempty" json:"recorded_by,omitempty"`
+	Notes      string    `yaml:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// recordFields is a Record without its YAML methods, used to avoid
+// recursion when decoding and encoding the mapping form.
+type recordFields Record
+
+// IsPlain reports whether the record holds only a codename.
+func (r Record) IsPlain() bool {
+	return r == Record{Codename: r.Codename}
+}
+
+// UnmarshalYAML accepts a plain codename string or a record mapping.
+func (r *Record) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*r = Record{Codename: node.Value}
+		return nil
+	}
+
+	var fields recordFields
+	if err := node.Decode(&fields); err != nil {
+		return err
+	}
+	*r = Record(fields)
+	return nil
+}
+
+// MarshalYAML writes plain records as a string so existing files keep their
+// compact form.
+func (r Record) MarshalYAML() (any, error) {
+	if r.IsPlain() {
+		return r.Codename, nil
+	}
+	return recordFields(r), nil
+}
+
+// VersionKey returns the used_codenames key for a release version: the
+// SemVer version without a leading "v".
+func VersionKey(version string) (string, error) {
+	key := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if !isVersionKey(key) {
+		return "", fmt.Errorf("invalid version %q (expected SemVer such as 1.2.3)", version)
+	}
+	return key, nil
+}
+
+// EnsureUnrecorded returns an error wrapping ErrRecorded when version
+// already has a record; recorded releases are never overwritten.
+func (c Config) EnsureUnrecorded(version string) error {
+	if existing, ok := c.Recorded(version); ok {
+		return fmt.Errorf("version %s is %w as %q", version, ErrRecorded, existing.Codename)
+	}
+	return nil
+}
+
+// Recorded returns the record stored for version, whether its key was
+// written with or without a leading "v".
+func (c Config) Recorded(version string) (Record, bool) {
+	key := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if record, ok := c.UsedCodenames[key]; ok {
+		return record, true
+	}
+	record, ok := c.UsedCodenames["v"+key]
+	return record, ok
+}
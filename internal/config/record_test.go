@@ -0,0 +1,101 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecord_DecodesPlainAndStructuredEntries(t *testing.T) {
+	payload := []byte(`schema_version: 2
+used_codenames:
+  0.1.0: Apricot
+  0.2.0:
+    codename: Aquamarine
+    theme: crayola_colors
+    seed: 42
+    recorded_at: 2026-03-01T12:00:00Z
+    commit: abc123
+    recorded_by: release@example.com
+    notes: hotfix
+`)
+
+	cfg, warnings, err := Decode(payload)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+
+	if plain := cfg.UsedCodenames["0.1.0"]; plain != (Record{Codename: "Apricot"}) || !plain.IsPlain() {
+		t.Fatalf("unexpected plain record: %+v", plain)
+	}
+
+	expected := Record{
+		Codename:   "Aquamarine",
+		Theme:      "crayola_colors",
+		Seed:       42,
+		RecordedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Commit:     "abc123",
+		RecordedBy: "release@example.com",
+		Notes:      "hotfix",
+	}
+	if got := cfg.UsedCodenames["0.2.0"]; got != expected {
+		t.Fatalf("unexpected record: %+v", got)
+	}
+}
+
+func TestRecord_MarshalKeepsPlainEntriesCompact(t *testing.T) {
+	cfg := Default()
+	cfg.UsedCodenames["0.1.0"] = Record{Codename: "Apricot"}
+	cfg.UsedCodenames["0.2.0"] = Record{Codename: "Aquamarine", Seed: 7}
+
+	payload, err := Marshal(cfg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	content := string(payload)
+	if !strings.Contains(content, "0.1.0: Apricot\n") {
+		t.Fatalf("expected plain entry as string, got:\n%s", content)
+	}
+	if !strings.Contains(content, "0.2.0:\n        codename: Aquamarine\n        seed: 7\n") {
+		t.Fatalf("expected structured entry, got:\n%s", content)
+	}
+}
+
+func TestRecord_UnknownFieldsWarn(t *testing.T) {
+	_, warnings, err := Decode([]byte("used_codenames:\n  0.1.0:\n    codename: Apricot\n    sha: abc\n"))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Key != "used_codenames.0.1.0.sha" {
+		t.Fatalf("expected unknown record field warning, got %v", warnings)
+	}
+}
+
+func TestVersionKeyAndRecorded(t *testing.T) {
+	key, err := VersionKey(" v1.2.0-rc.1 ")
+	if err != nil || key != "1.2.0-rc.1" {
+		t.Fatalf("expected 1.2.0-rc.1, got %q (%v)", key, err)
+	}
+	for _, invalid := range []string{"", "latest", "1.2", UnreleasedKey} {
+		if _, err := VersionKey(invalid); err == nil {
+			t.Fatalf("expected error for %q", invalid)
+		}
+	}
+
+	cfg := Default()
+	cfg.UsedCodenames["v0.1.0"] = Record{Codename: "Apricot"}
+	if record, ok := cfg.Recorded("0.1.0"); !ok || record.Codename != "Apricot" {
+		t.Fatalf("expected v-prefixed key to be found, got %+v %v", record, ok)
+	}
+	if _, ok := cfg.Recorded("0.2.0"); ok {
+		t.Fatalf("expected 0.2.0 to be unrecorded")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Repository hosts with built-in changelog link templates. HostCustom uses
+// only the templates set in the config.
+const (
+	HostGitHub    = "github"
+	HostGitLab    = "gitlab"
+	HostGitea     = "gitea"
+	HostForgejo   = "forgejo"
+	HostBitbucket = "bitbucket"
+	HostCustom    = "custom"
+)
+
+// RepositoryHosts lists the supported repository.host values.
+var RepositoryHosts = []string{HostGitHub, HostGitLab, HostGitea, HostForgejo, HostBitbucket, HostCustom}
+
+// Repository describes where the project is hosted, for the compare and
+// release links the release command writes to CHANGELOG.md. Empty fields
+// are derived from the git origin remote.
+type Repository struct {
+	// URL is the web address of the repository, such as
+	// https://github.com/owner/project.
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+	// Host selects the link templates; empty detects it from URL.
+	Host string `yaml:"host,omitempty" json:"host,omitempty"`
+	// CompareURL overrides the compare link template. {url}, {from} and
+	// {to} are replaced with the repository URL and the two tags.
+	CompareURL string `yaml:"compare_url,omitempty" json:"compare_url,omitempty"`
+	// ReleaseURL overrides the link template for the first release. {url},
+	// {tag} and {version} are replaced.
+	ReleaseURL string `yaml:"release_url,omitempty" json:"release_url,omitempty"`
+}
+
+// IsZero reports whether nothing is configured.
+func (r Repository) IsZero() bool {
+	return r == Repository{}
+}
+
+func repositoryFindings(repo Repository) []Finding {
+	var findings []Finding
+	add := func(severity, key, format string, args ...any) {
+		findings = append(findings, Finding{Severity: severity, Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if repo.URL != "" {
+		parsed, err := url.Parse(repo.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			add(SeverityError, "repository.url", "%q is not an http(s) URL", repo.URL)
+		}
+	}
+
+	known := false
+	for _, host := range RepositoryHosts {
+		known = known || repo.Host == host
+	}
+	if repo.Host != "" && !known {
+		add(SeverityError, "repository.host", "unknown host %q (supported: %s)", repo.Host, strings.Join(RepositoryHosts, ", "))
+	}
+	if repo.Host == HostCustom && (repo.CompareURL == "" || repo.ReleaseURL == "") {
+		add(SeverityError, "repository.host", "custom hosts need compare_url and release_url")
+	}
+
+	if repo.CompareURL != "" && (!strings.Contains(repo.CompareURL, "{from}") || !strings.Contains(repo.CompareURL, "{to}")) {
+		add(SeverityError, "repository.compare_url", "must contain {from} and {to}")
+	}
+	if repo.ReleaseURL != "" && !strings.Contains(repo.ReleaseURL, "{tag}") && !strings.Contains(repo.ReleaseURL, "{version}") {
+		add(SeverityError, "repository.release_url", "must contain {tag} or {version}")
+	}
+	return findings
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchemaVersion is the config layout written by this version of
+// TAGtastic.
+//
+// Schema history:
+//
+//	0: legacy flat layout with top-level "<version>: <codename>" entries
+//	1: structured layout with used_codenames, api and schema_version
+//	2: used_codenames entries may be records (codename, theme, seed, ...)
+const CurrentSchemaVersion = 2
+
+// ErrParse is wrapped by errors for config files that are not valid YAML or
+// do not have a mapping at the top level.
+var ErrParse = errors.New("parse config")
+
+var knownKeys = map[string]map[string]bool{
+	"schema_version": nil,
+	"default_theme":  nil,
+	"default_format": nil,
+	"used_codenames": nil,
+	"api": {
+		"enabled":   true,
+		"endpoint":  true,
+		"cache_dir": true,
+		"cache_ttl": true,
+	},
+	"policy": {
+		"letter_distance": true,
+		"length":          true,
+		"themes":          true,
+		"reuse_window":    true,
+	},
+	"repository": {
+		"url":         true,
+		"host":        true,
+		"compare_url": true,
+		"release_url": true,
+	},
+}
+
+var recordKeys = map[string]bool{
+	"codename":    true,
+	"theme":       true,
+	"seed":        true,
+	"recorded_at": true,
+	"commit":      true,
+	"recorded_by": true,
+	"notes":       true,
+}
+
+// Warning describes a non-fatal problem found while reading a config file.
+type Warning struct {
+	Key     string
+	Message string
+}
+
+func (w Warning) String() string {
+	if w.Key == "" {
+		return w.Message
+	}
+	return fmt.Sprintf("%s: %s", w.Key, w.Message)
+}
+
+// Migration is the result of rewriting a config file to the current schema.
+type Migration struct {
+	From     int
+	To       int
+	Config   Config
+	Warnings []Warning
+	Payload  []byte
+	Changed  bool
+}
+
+// Decode parses a config payload. Legacy top-level version entries are
+// folded into UsedCodenames, and unknown keys are reported as warnings
+// instead of being dropped silently.
+func Decode(payload []byte) (Config, []Warning, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(payload, &root); err != nil {
+		return Config{}, nil, fmt.Errorf("%w: %w", ErrParse, err)
+	}
+
+	mapping := documentMapping(&root)
+	if mapping == nil {
+		if root.Kind == 0 {
+			return Config{}, nil, nil
+		}
+		return Config{}, nil, fmt.Errorf("%w: top level must be a mapping", ErrParse)
+	}
+
+	var cfg Config
+	if err := mapping.Decode(&cfg); err != nil {
+		return Config{}, nil, fmt.Errorf("%w: %w", ErrParse, err)
+	}
+
+	var warnings []Warning
+	legacy := 0
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		nested, known := knownKeys[key.Value]
+		switch {
+		case known:
+			if nested != nil && value.Kind == yaml.MappingNode {
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					child := value.Content[j].Value
+					if !nested[child] {
+						warnings = append(warnings, Warning{Key: key.Value + "." + child, Message: "unknown key ignored"})
+					}
+				}
+			}
+			if key.Value == "used_codenames" && value.Kind == yaml.MappingNode {
+				warnings = append(warnings, recordWarnings(value)...)
+			}
+		case isVersionKey(key.Value) && value.Kind == yaml.ScalarNode:
+			if cfg.UsedCodenames == nil {
+				cfg.UsedCodenames = map[string]Record{}
+			}
+			if _, exists := cfg.UsedCodenames[key.Value]; !exists {
+				cfg.UsedCodenames[key.Value] = Record{Codename: value.Value}
+			}
+			legacy++
+		default:
+			warnings = append(warnings, Warning{Key: key.Value, Message: "unknown key ignored"})
+		}
+	}
+
+	if legacy > 0 {
+		warnings = append([]Warning{{
+			Message: fmt.Sprintf("legacy flat layout detected (%d top-level version entries); run \"tagtastic config migrate\"", legacy),
+		}}, warnings...)
+	}
+	if cfg.SchemaVersion > CurrentSchemaVersion {
+		warnings = append(warnings, Warning{
+			Key:     "schema_version",
+			Message: fmt.Sprintf("schema %d is newer than supported schema %d", cfg.SchemaVersion, CurrentSchemaVersion),
+		})
+	}
+
+	return cfg, warnings, nil
+}
+
+// DetectSchemaVersion reports the layout version of a payload. Files with
+// top-level version entries are schema 0; structured files without an
+// explicit schema_version are treated as schema 1.
+func DetectSchemaVersion(payload []byte) (int, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(payload, &root); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrParse, err)
+	}
+
+	mapping := documentMapping(&root)
+	if mapping == nil {
+		return CurrentSchemaVersion, nil
+	}
+
+	version := 1
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if _, known := knownKeys[key.Value]; !known && isVersionKey(key.Value) && value.Kind == yaml.ScalarNode {
+			return 0, nil
+		}
+		if key.Value == "schema_version" {
+			if err := value.Decode(&version); err != nil {
+				return 0, fmt.Errorf("parse schema_version: %w", err)
+			}
+		}
+	}
+
+	return version, nil
+}
+
+// Migrate rewrites a payload in the current schema layout. Files already at
+// the current schema are returned unchanged; older ones are upgraded in
+// place through a Document, so comments and formatting survive.
+func Migrate(payload []byte) (Migration, error) {
+	from, err := DetectSchemaVersion(payload)
+	if err != nil {
+		return Migration{}, err
+	}
+
+	cfg, warnings, err := Decode(payload)
+	if err != nil {
+		return Migration{}, err
+	}
+	if cfg.UsedCodenames == nil {
+		cfg.UsedCodenames = map[string]Record{}
+	}
+
+	migration := Migration{
+		From:     from,
+		To:       max(from, CurrentSchemaVersion),
+		Warnings: warnings,
+		Payload:  payload,
+		Changed:  from < CurrentSchemaVersion,
+	}
+	if migration.Changed {
+		doc := newDocument(payload)
+		if from == 0 {
+			if err := doc.foldLegacy(); err != nil {
+				return Migration{}, err
+			}
+		}
+		if err := doc.stampSchema(); err != nil {
+			return Migration{}, err
+		}
+		migration.Payload = doc.Bytes()
+	}
+	cfg.SchemaVersion = migration.To
+	migration.Config = cfg
+
+	return migration, nil
+}
+
+func recordWarnings(records *yaml.Node) []Warning {
+	var warnings []Warning
+	for i := 0; i+1 < len(records.Content); i += 2 {
+		version, record := records.Content[i].Value, records.Content[i+1]
+		if record.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(record.Content); j += 2 {
+			if field := record.Content[j].Value; !recordKeys[field] {
+				warnings = append(warnings, Warning{Key: "used_codenames." + version + "." + field, Message: "unknown key ignored"})
+			}
+		}
+	}
+	return warnings
+}
+
+func documentMapping(root *yaml.Node) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	return node
+}
+
+func isVersionKey(key string) bool {
+	trimmed := strings.TrimSpace(key)
+	if trimmed == "" {
+		return false
+	}
+	if !strings.HasPrefix(trimmed, "v") {
+		trimmed = "v" + trimmed
+	}
+	return semver.IsValid(trimmed) && semver.Canonical(trimmed) == trimmed
+}
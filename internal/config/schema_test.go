@@ -0,0 +1,118 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecode_LegacyFlatLayout(t *testing.T) {
+	payload := []byte("0.1.0-beta.1: Almond\n0.1.0-beta.2: Apricot\n")
+
+	cfg, warnings, err := Decode(payload)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if cfg.UsedCodenames["0.1.0-beta.1"].Codename != "Almond" || cfg.UsedCodenames["0.1.0-beta.2"].Codename != "Apricot" {
+		t.Fatalf("expected legacy entries to be read, got %v", cfg.UsedCodenames)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Message, "legacy") {
+		t.Fatalf("expected legacy warning, got %v", warnings)
+	}
+
+	version, err := DetectSchemaVersion(payload)
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if version != 0 {
+		t.Fatalf("expected schema 0, got %d", version)
+	}
+}
+
+func TestDecode_UnknownKeysWarn(t *testing.T) {
+	payload := []byte("default_thme: birds\napi:\n  endpont: x\n")
+
+	_, warnings, err := Decode(payload)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	keys := map[string]bool{}
+	for _, warning := range warnings {
+		keys[warning.Key] = true
+	}
+	if !keys["default_thme"] || !keys["api.endpont"] {
+		t.Fatalf("expected unknown key warnings, got %v", warnings)
+	}
+}
+
+func TestMigrate_LegacyToCurrent(t *testing.T) {
+	payload := []byte("0.1.0-beta.1: Almond\n")
+
+	migration, err := Migrate(payload)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if migration.From != 0 || migration.To != CurrentSchemaVersion || !migration.Changed {
+		t.Fatalf("unexpected migration: %+v", migration)
+	}
+
+	content := string(migration.Payload)
+	if !strings.Contains(content, "schema_version: 2") || !strings.Contains(content, "0.1.0-beta.1: Almond") {
+		t.Fatalf("unexpected migrated payload:\n%s", content)
+	}
+
+	again, err := Migrate(migration.Payload)
+	if err != nil {
+		t.Fatalf("migrate again: %v", err)
+	}
+	if again.Changed {
+		t.Fatalf("expected migrated payload to be stable")
+	}
+}
+
+func TestMigrate_KeepsFormatting(t *testing.T) {
+	current := []byte("# release names\nschema_version: 2\ndefault_theme: birds # house theme\n\nused_codenames:\n  0.1.0: Almond\n  0.2.0:\n    codename: Apricot\n    notes: second\n")
+	migration, err := Migrate(current)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if migration.Changed || string(migration.Payload) != string(current) {
+		t.Fatalf("expected current schema to be left unchanged, got %+v:\n%s", migration, migration.Payload)
+	}
+
+	structured := []byte("# release names\ndefault_theme: birds # house theme\nused_codenames:\n  0.1.0: Almond\n")
+	migration, err = Migrate(structured)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	want := "# release names\nschema_version: 2\ndefault_theme: birds # house theme\nused_codenames:\n  0.1.0: Almond\n"
+	if migration.From != 1 || !migration.Changed || string(migration.Payload) != want {
+		t.Fatalf("unexpected migration from schema 1 (%+v):\n%s", migration, migration.Payload)
+	}
+
+	legacy := []byte("# release names\ndefault_theme: birds\n0.1.0: Almond # first\n")
+	migration, err = Migrate(legacy)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	content := string(migration.Payload)
+	if !strings.Contains(content, "# release names") || !strings.Contains(content, "0.1.0: Almond # first") || !strings.HasPrefix(strings.TrimPrefix(content, "# release names\n"), "schema_version: 2\n") {
+		t.Fatalf("unexpected legacy migration:\n%s", content)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	diff := Diff("a", "b", []byte("one\ntwo\nthree\n"), []byte("one\n2\nthree\n"))
+	want := "--- a\n+++ b\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n"
+	if diff != want {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+	if Diff("a", "b", []byte("same\n"), []byte("same\n")) != "" {
+		t.Fatalf("expected empty diff for identical input")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnreleasedKey is the used_codenames entry for a codename recorded before
+// its version is known.
+const UnreleasedKey = "unreleased"
+
+// Finding severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Finding is a single result of Validate.
+type Finding struct {
+	Severity string `json:"severity"`
+	Key      string `json:"key,omitempty"`
+	Message  string `json:"message"`
+}
+
+// ValidateOptions supplies the cross-checks that depend on other packages.
+// Nil callbacks skip the corresponding check.
+type ValidateOptions struct {
+	ThemeExists   func(name string) bool
+	FormatKnown   func(name string) bool
+	CodenameKnown func(name string) bool
+}
+
+// Validate decodes payload strictly and cross-checks its values.
+func Validate(payload []byte, opts ValidateOptions) []Finding {
+	findings := []Finding{}
+
+	if version, err := DetectSchemaVersion(payload); err == nil && version == 0 {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Message:  "legacy flat layout; run \"tagtastic config migrate\"",
+		})
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(payload))
+	decoder.KnownFields(true)
+	var strict Config
+	if err := decoder.Decode(&strict); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			for _, message := range typeErr.Errors {
+				findings = append(findings, strictFinding(message))
+			}
+		} else {
+			return append(findings, Finding{Severity: SeverityError, Message: err.Error()})
+		}
+	}
+
+	cfg, warnings, err := Decode(payload)
+	if err != nil {
+		return append(findings, Finding{Severity: SeverityError, Message: err.Error()})
+	}
+
+	switch {
+	case cfg.SchemaVersion == 0:
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Key:      "schema_version",
+			Message:  "not set; run \"tagtastic config migrate\"",
+		})
+	case cfg.SchemaVersion > CurrentSchemaVersion:
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Key:      "schema_version",
+			Message:  fmt.Sprintf("schema %d is newer than supported schema %d", cfg.SchemaVersion, CurrentSchemaVersion),
+		})
+	}
+
+	if cfg.DefaultTheme != "" && opts.ThemeExists != nil && !opts.ThemeExists(cfg.DefaultTheme) {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Key:      "default_theme",
+			Message:  fmt.Sprintf("theme %q does not exist", cfg.DefaultTheme),
+		})
+	}
+
+	if cfg.DefaultFormat != "" && opts.FormatKnown != nil && !opts.FormatKnown(cfg.DefaultFormat) {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Key:      "default_format",
+			Message:  fmt.Sprintf("format %q is not a known formatter", cfg.DefaultFormat),
+		})
+	}
+
+	versions := make([]string, 0, len(cfg.UsedCodenames))
+	for version := range cfg.UsedCodenames {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	for _, version := range versions {
+		key := "used_codenames." + version
+		if version != UnreleasedKey && !isVersionKey(version) {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Key:      key,
+				Message:  fmt.Sprintf("%q is not a valid SemVer version", version),
+			})
+		}
+
+		record := cfg.UsedCodenames[version]
+		codename := strings.TrimSpace(record.Codename)
+		switch {
+		case codename == "":
+			findings = append(findings, Finding{Severity: SeverityError, Key: key, Message: "codename is empty"})
+		case opts.CodenameKnown != nil && !opts.CodenameKnown(codename):
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Key:      key,
+				Message:  fmt.Sprintf("codename %q no longer exists in any theme", codename),
+			})
+		}
+		if record.Theme != "" && opts.ThemeExists != nil && !opts.ThemeExists(record.Theme) {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Key:      key + ".theme",
+				Message:  fmt.Sprintf("theme %q does not exist", record.Theme),
+			})
+		}
+	}
+
+	// Records decode through their own unmarshaler, so strict decoding does
+	// not see their fields.
+	for _, warning := range warnings {
+		if strings.HasPrefix(warning.Key, "used_codenames.") {
+			findings = append(findings, Finding{Severity: SeverityError, Key: warning.Key, Message: "unknown key"})
+		}
+	}
+
+	if ttl := strings.TrimSpace(cfg.API.CacheTTL); ttl != "" {
+		if _, err := time.ParseDuration(ttl); err != nil {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Key:      "api.cache_ttl",
+				Message:  fmt.Sprintf("%q is not a valid duration (e.g. 30m, 24h)", ttl),
+			})
+		}
+	}
+
+	findings = append(findings, policyFindings(cfg.Policy, opts)...)
+	findings = append(findings, repositoryFindings(cfg.Repository)...)
+
+	return findings
+}
+
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (\S+) not found in type`)
+
+func strictFinding(message string) Finding {
+	if match := unknownFieldPattern.FindStringSubmatch(message); match != nil {
+		return Finding{
+			Severity: SeverityError,
+			Key:      match[2],
+			Message:  fmt.Sprintf("unknown key (line %s)", match[1]),
+		}
+	}
+	return Finding{Severity: SeverityError, Message: message}
+}
+
+// HasErrors reports whether any finding is an error.
+func HasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package config
+
+import "testing"
+
+func TestValidate_Findings(t *testing.T) {
+	payload := []byte(`schema_version: 1
+default_thme: birds
+default_theme: planets
+default_format: xml
+used_codenames:
+  0.1.0: Almond
+  latest: Apricot
+  0.2.0: Vanished
+  0.3.0:
+    codename: Apricot
+    theme: oceans
+    sha: abc123
+api:
+  cache_ttl: soon
+`)
+
+	findings := Validate(payload, ValidateOptions{
+		ThemeExists:   func(name string) bool { return name == "birds" },
+		FormatKnown:   func(name string) bool { return name == "text" },
+		CodenameKnown: func(name string) bool { return name != "Vanished" },
+	})
+
+	want := map[string]string{
+		"default_thme":               SeverityError,
+		"default_theme":              SeverityError,
+		"default_format":             SeverityError,
+		"used_codenames.latest":      SeverityError,
+		"used_codenames.0.2.0":       SeverityWarning,
+		"used_codenames.0.3.0.theme": SeverityWarning,
+		"used_codenames.0.3.0.sha":   SeverityError,
+		"api.cache_ttl":              SeverityError,
+	}
+	got := map[string]string{}
+	for _, finding := range findings {
+		got[finding.Key] = finding.Severity
+	}
+	for key, severity := range want {
+		if got[key] != severity {
+			t.Fatalf("expected %s finding for %s, got findings %+v", severity, key, findings)
+		}
+	}
+	if _, ok := got["used_codenames.0.1.0"]; ok {
+		t.Fatalf("expected valid entry to pass, got %+v", findings)
+	}
+	if !HasErrors(findings) {
+		t.Fatalf("expected HasErrors to be true")
+	}
+}
+
+func TestValidate_Clean(t *testing.T) {
+	payload, err := Marshal(Default())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	findings := Validate(payload, ValidateOptions{
+		ThemeExists: func(string) bool { return true },
+		FormatKnown: func(string) bool { return true },
+	})
+	if len(findings) != 0 {
+		t.Fatalf("expected no findings for default config, got %+v", findings)
+	}
+}
+
+func TestValidate_Policy(t *testing.T) {
+	payload := []byte(`schema_version: 2
+policy:
+  letter_distance: 30
+  reuse_window: -1
+  length:
+    - versions: "[1"
+      max: 10
+    - min: 12
+      max: 8
+  themes:
+    - versions: "*-lts*"
+      reserve: [galaxies]
+      allow: [birds]
+    - versions: "*.0.0"
+      only: [birds]
+`)
+
+	findings := Validate(payload, ValidateOptions{
+		ThemeExists: func(name string) bool { return name == "birds" },
+	})
+
+	want := map[string]string{
+		"policy.letter_distance":      SeverityError,
+		"policy.reuse_window":         SeverityError,
+		"policy.length[0].versions":   SeverityError,
+		"policy.length[1]":            SeverityError,
+		"policy.themes[0].reserve[0]": SeverityError,
+		"policy.themes[1]":            SeverityWarning,
+		"only":                        SeverityError,
+	}
+	got := map[string]string{}
+	for _, finding := range findings {
+		got[finding.Key] = finding.Severity
+	}
+	for key, severity := range want {
+		if got[key] != severity {
+			t.Fatalf("expected %s finding for %s, got findings %+v", severity, key, findings)
+		}
+	}
+	if _, ok := got["policy.themes[0].allow[0]"]; ok {
+		t.Fatalf("expected existing theme to pass, got %+v", findings)
+	}
+}
+
+func TestValidate_Repository(t *testing.T) {
+	payload := []byte(`schema_version: 2
+repository:
+  url: git@github.com:infravillage/tagtastic.git
+  host: sourcehut
+  compare_url: "{url}/compare/{to}"
+  release_url: "{url}/releases"
+`)
+
+	findings := Validate(payload, ValidateOptions{})
+
+	want := []string{"repository.url", "repository.host", "repository.compare_url", "repository.release_url"}
+	got := map[string]string{}
+	for _, finding := range findings {
+		got[finding.Key] = finding.Severity
+	}
+	for _, key := range want {
+		if got[key] != SeverityError {
+			t.Fatalf("expected error finding for %s, got findings %+v", key, findings)
+		}
+	}
+
+	clean := []byte(`schema_version: 2
+repository:
+  url: https://git.example.com/team/tagtastic
+  host: custom
+  compare_url: "{url}/diff/{from}..{to}"
+  release_url: "{url}/tags/{tag}"
+`)
+	if findings := Validate(clean, ValidateOptions{}); len(findings) != 0 {
+		t.Fatalf("expected no findings for a custom host, got %+v", findings)
+	}
+}
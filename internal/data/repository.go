@@ -76,47 +76,13 @@ func (r *EmbeddedThemeRepository) GetThemeByName(name string) (*Theme, error) {
 	if theme, ok := r.themes[normalizeName(name)]; ok {
 		return theme, nil
 	}
-	return nil, ErrThemeNotFound
+	return nil, fmt.Errorf("%w: %s", ErrThemeNotFound, name)
 }
 
 func (r *EmbeddedThemeRepository) GetAllThemeNames() []string {
 	return append([]string(nil), r.names...)
 }
 
-func FilterItems(items []CodeName, exclude []string) []CodeName {
-	if len(exclude) == 0 {
-		return append([]CodeName(nil), items...)
-	}
-
-	deny := make(map[string]struct{}, len(exclude))
-	for _, raw := range exclude {
-		key := normalizeName(raw)
-		if key != "" {
-			deny[key] = struct{}{}
-		}
-	}
-
-	filtered := make([]CodeName, 0, len(items))
-	for _, item := range items {
-		if shouldExclude(item, deny) {
-			continue
-		}
-		filtered = append(filtered, item)
-	}
-
-	return filtered
-}
-
-func shouldExclude(item CodeName, deny map[string]struct{}) bool {
-	candidates := append([]string{item.Name}, item.Aliases...)
-	for _, candidate := range candidates {
-		if _, ok := deny[normalizeName(candidate)]; ok {
-			return true
-		}
-	}
-	return false
-}
-
 func normalizeName(input string) string {
 	trimmed := strings.TrimSpace(strings.ToLower(input))
 	if trimmed == "" {
@@ -48,25 +48,6 @@ func TestEmbeddedThemeRepository_GetAllThemeNames(t *testing.T) {
 	}
 }
 
-func TestFilterItems_Exclude(t *testing.T) {
-	repo, err := NewEmbeddedThemeRepository()
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-
-	theme, err := repo.GetThemeByName("birds")
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-
-	filtered := FilterItems(theme.Items, []string{"blue-heron", "albatross"})
-	for _, item := range filtered {
-		if normalizeName(item.Name) == "blue-heron" || normalizeName(item.Name) == "albatross" {
-			t.Fatalf("expected item %q to be filtered out", item.Name)
-		}
-	}
-}
-
 func TestGetThemeByName_NotFound(t *testing.T) {
 	repo, err := NewEmbeddedThemeRepository()
 	if err != nil {
@@ -78,25 +59,6 @@ func TestGetThemeByName_NotFound(t *testing.T) {
 	}
 }
 
-func TestFilterItems_ExcludeAlias(t *testing.T) {
-	repo, err := NewEmbeddedThemeRepository()
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-
-	theme, err := repo.GetThemeByName("birds")
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-
-	filtered := FilterItems(theme.Items, []string{"heron"})
-	for _, item := range filtered {
-		if normalizeName(item.Name) == "blue-heron" {
-			t.Fatalf("expected alias exclusion to remove Blue Heron")
-		}
-	}
-}
-
 func TestThemesHaveAliases(t *testing.T) {
 	repo, err := NewEmbeddedThemeRepository()
 	if err != nil {
@@ -119,3 +81,31 @@ func TestThemesHaveAliases(t *testing.T) {
 		}
 	}
 }
+
+func TestEmbeddedThemeRepository_ColorItems(t *testing.T) {
+	repo, err := NewEmbeddedThemeRepository()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	theme, err := repo.GetThemeByName("crayola_colors")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, item := range theme.Items {
+		if _, _, _, ok := ParseColor(item.Attributes[AttrHex]); !ok {
+			t.Fatalf("%s: expected a #RRGGBB color, got %q", item.Name, item.Attributes[AttrHex])
+		}
+		if !strings.EqualFold(item.Description, "Hex "+item.Attributes[AttrHex]) {
+			t.Fatalf("%s: color %s does not match description %q", item.Name, item.Attributes[AttrHex], item.Description)
+		}
+	}
+
+	r, g, b, ok := ParseColor("#EFDECD")
+	if !ok || r != 0xEF || g != 0xDE || b != 0xCD {
+		t.Fatalf("unexpected RGB: %d %d %d %v", r, g, b, ok)
+	}
+	if _, _, _, ok := ParseColor("EFDECD"); ok {
+		t.Fatalf("expected missing # to be rejected")
+	}
+}
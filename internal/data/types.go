@@ -5,10 +5,39 @@
 
 package data
 
+import "strconv"
+
 type CodeName struct {
-	Name        string   `yaml:"name" json:"name"`
-	Aliases     []string `yaml:"aliases" json:"aliases"`
-	Description string   `yaml:"description" json:"description"`
+	Name        string     `yaml:"name" json:"name"`
+	Aliases     []string   `yaml:"aliases" json:"aliases"`
+	Description string     `yaml:"description" json:"description"`
+	Attributes  Attributes `yaml:"attributes,omitempty" json:"attributes,omitempty"`
+}
+
+// Attributes holds structured facts about an item, keyed by the Attr*
+// constants, so items can be queried without parsing descriptions.
+type Attributes map[string]string
+
+// Well-known attribute keys. Themes may define others.
+const (
+	// AttrHex is a color as #RRGGBB.
+	AttrHex       = "hex"
+	AttrCountry   = "country"
+	AttrContinent = "continent"
+	AttrEmoji     = "emoji"
+	AttrURL       = "url"
+)
+
+// ParseColor parses a #RRGGBB color.
+func ParseColor(color string) (r, g, b uint8, ok bool) {
+	if len(color) != 7 || color[0] != '#' {
+		return 0, 0, 0, false
+	}
+	value, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(value >> 16), uint8(value >> 8), uint8(value), true
 }
 
 type Theme struct {
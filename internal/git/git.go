@@ -0,0 +1,95 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+// Package git reads the repository details attached to release records.
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Info describes the repository state when a codename is recorded.
+type Info struct {
+	Commit    string
+	UserEmail string
+}
+
+// Describe returns the HEAD commit and configured user.email for the
+// repository containing dir. Values that cannot be read (no repository, no
+// commits yet, git not installed) are left empty.
+func Describe(dir string) Info {
+	return Info{
+		Commit:    run(dir, "rev-parse", "HEAD"),
+		UserEmail: run(dir, "config", "user.email"),
+	}
+}
+
+// RemoteURL returns the fetch URL of the named remote, or "" when the
+// remote does not exist.
+func RemoteURL(dir, remote string) string {
+	return run(dir, "remote", "get-url", remote)
+}
+
+// Tag is a release tag and the first line of its message. Subject is empty
+// for lightweight tags.
+type Tag struct {
+	Name    string
+	Commit  string
+	Subject string
+}
+
+// Tags lists the "v*" tags of the repository containing dir. The commit is
+// the tagged commit, also for annotated tags.
+func Tags(dir string) ([]Tag, error) {
+	// #nosec G204 - arguments are fixed by this package
+	cmd := exec.Command("git", "-C", dir, "tag", "-l", "v*",
+		"--format=%(refname:short)%00%(*objectname)%00%(objectname)%00%(contents:subject)")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list git tags: %w", err)
+	}
+
+	var tags []Tag
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) != 4 || fields[0] == "" {
+			continue
+		}
+		tag := Tag{Name: fields[0], Commit: fields[1], Subject: strings.TrimSpace(fields[3])}
+		if tag.Commit == "" {
+			// Lightweight tag: the subject is the commit's, not a tag message.
+			tag.Commit, tag.Subject = fields[2], ""
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// TagCodename extracts the codename from a tag subject written by the
+// release helper, such as "v1.2.3 – Name" (a hyphen also works).
+func TagCodename(subject string) string {
+	if subject == "" {
+		return ""
+	}
+	if idx := strings.Index(subject, "– "); idx != -1 {
+		return strings.TrimSpace(subject[idx+len("– "):])
+	}
+	if idx := strings.Index(subject, "- "); idx != -1 {
+		return strings.TrimSpace(subject[idx+len("- "):])
+	}
+	return ""
+}
+
+func run(dir string, args ...string) string {
+	// #nosec G204 - arguments are fixed by this package
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestDescribe_Repository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "release@example.com"},
+		{"config", "user.name", "Release Bot"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	info := Describe(dir)
+	if len(info.Commit) != 40 {
+		t.Fatalf("expected full commit SHA, got %q", info.Commit)
+	}
+	if info.UserEmail != "release@example.com" {
+		t.Fatalf("expected user email, got %q", info.UserEmail)
+	}
+}
+
+func TestTags(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "release@example.com"},
+		{"config", "user.name", "Release Bot"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+		{"tag", "-a", "v0.1.0", "-m", "v0.1.0 – Apricot"},
+		{"tag", "v0.2.0"},
+		{"tag", "other"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	tags, err := Tags(dir)
+	if err != nil {
+		t.Fatalf("tags: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("expected two v* tags, got %+v", tags)
+	}
+	head := Describe(dir).Commit
+	if tags[0].Name != "v0.1.0" || tags[0].Commit != head || TagCodename(tags[0].Subject) != "Apricot" {
+		t.Fatalf("unexpected annotated tag: %+v", tags[0])
+	}
+	if tags[1].Name != "v0.2.0" || tags[1].Commit != head || TagCodename(tags[1].Subject) != "" {
+		t.Fatalf("unexpected lightweight tag: %+v", tags[1])
+	}
+}
+
+func TestTagCodename(t *testing.T) {
+	cases := map[string]string{
+		"v1.2.3 – Atomic Tangerine": "Atomic Tangerine",
+		"v1.2.3 - Almond":           "Almond",
+		"initial":                   "",
+		"":                          "",
+	}
+	for subject, want := range cases {
+		if got := TagCodename(subject); got != want {
+			t.Fatalf("TagCodename(%q) = %q, want %q", subject, got, want)
+		}
+	}
+}
+
+func TestDescribe_NotARepository(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	info := Describe(dir)
+	if info.Commit != "" || info.UserEmail != "" {
+		t.Fatalf("expected empty info outside a repository, got %+v", info)
+	}
+}
+
+func TestRemoteURL(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"remote", "add", "origin", "git@github.com:acme/app.git"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	if remote := RemoteURL(dir, "origin"); remote != "git@github.com:acme/app.git" {
+		t.Fatalf("unexpected origin URL %q", remote)
+	}
+	if remote := RemoteURL(dir, "upstream"); remote != "" {
+		t.Fatalf("expected no upstream remote, got %q", remote)
+	}
+}
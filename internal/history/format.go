@@ -0,0 +1,129 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package history
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Formats lists the output formats accepted by Format.
+var Formats = []string{"text", "json", "csv", "markdown"}
+
+// ErrUnknownFormat is returned by Format for unsupported formats.
+var ErrUnknownFormat = errors.New("unknown history format")
+
+var columns = []string{"version", "codename", "theme", "seed", "recorded_at", "commit", "recorded_by", "notes"}
+
+// Format renders entries as a text table, JSON array, CSV or a Markdown
+// table.
+func Format(entries []Entry, format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "text":
+		return formatText(entries), nil
+	case "json":
+		if entries == nil {
+			entries = []Entry{}
+		}
+		encoded, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	case "csv":
+		return formatCSV(entries)
+	case "markdown", "md":
+		return formatMarkdown(entries), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+func formatText(entries []Entry) string {
+	if len(entries) == 0 {
+		return "No recorded releases."
+	}
+
+	var buf bytes.Buffer
+	writer := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "VERSION\tCODENAME\tTHEME\tRECORDED")
+	for _, entry := range entries {
+		recorded := "-"
+		if !entry.RecordedAt.IsZero() {
+			recorded = entry.RecordedAt.Format("2006-01-02")
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", entry.Version, entry.Codename, dash(entry.Theme), recorded)
+	}
+	_ = writer.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func formatCSV(entries []Entry) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(columns); err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if err := writer.Write(row(entry)); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func formatMarkdown(entries []Entry) string {
+	lines := []string{
+		"| Version | Codename | Theme | Recorded | Notes |",
+		"| --- | --- | --- | --- | --- |",
+	}
+	for _, entry := range entries {
+		recorded := ""
+		if !entry.RecordedAt.IsZero() {
+			recorded = entry.RecordedAt.Format("2006-01-02")
+		}
+		cells := []string{entry.Version, entry.Codename, entry.Theme, recorded, entry.Notes}
+		for i, cell := range cells {
+			cells[i] = markdownCell(cell)
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func row(entry Entry) []string {
+	seed, recorded := "", ""
+	if entry.Seed != 0 {
+		seed = strconv.FormatInt(entry.Seed, 10)
+	}
+	if !entry.RecordedAt.IsZero() {
+		recorded = entry.RecordedAt.Format(time.RFC3339)
+	}
+	return []string{entry.Version, entry.Codename, entry.Theme, seed, recorded, entry.Commit, entry.RecordedBy, entry.Notes}
+}
+
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
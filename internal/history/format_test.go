@@ -0,0 +1,84 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/infravillage/tagtastic/internal/config"
+)
+
+func formatEntries() []Entry {
+	return []Entry{
+		{Version: "0.1.0", Record: config.Record{Codename: "Apricot"}},
+		{Version: "0.2.0", Record: config.Record{
+			Codename:   "Aquamarine",
+			Theme:      "crayola_colors",
+			Seed:       42,
+			RecordedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+			Commit:     "abc123",
+			RecordedBy: "release@example.com",
+			Notes:      "fixes | \"quoted\", commas",
+		}},
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := map[string]string{
+		"text": "VERSION  CODENAME    THEME           RECORDED\n" +
+			"0.1.0    Apricot     -               -\n" +
+			"0.2.0    Aquamarine  crayola_colors  2026-03-01",
+		"csv": "version,codename,theme,seed,recorded_at,commit,recorded_by,notes\n" +
+			"0.1.0,Apricot,,,,,,\n" +
+			"0.2.0,Aquamarine,crayola_colors,42,2026-03-01T12:00:00Z,abc123,release@example.com,\"fixes | \"\"quoted\"\", commas\"",
+		"markdown": "| Version | Codename | Theme | Recorded | Notes |\n" +
+			"| --- | --- | --- | --- | --- |\n" +
+			"| 0.1.0 | Apricot |  |  |  |\n" +
+			"| 0.2.0 | Aquamarine | crayola_colors | 2026-03-01 | fixes \\| \"quoted\", commas |",
+		"json": `[
+  {
+    "version": "0.1.0",
+    "codename": "Apricot"
+  },
+  {
+    "version": "0.2.0",
+    "codename": "Aquamarine",
+    "theme": "crayola_colors",
+    "seed": 42,
+    "recorded_at": "2026-03-01T12:00:00Z",
+    "commit": "abc123",
+    "recorded_by": "release@example.com",
+    "notes": "fixes | \"quoted\", commas"
+  }
+]`,
+	}
+
+	for format, want := range cases {
+		t.Run(format, func(t *testing.T) {
+			got, err := Format(formatEntries(), format)
+			if err != nil {
+				t.Fatalf("format: %v", err)
+			}
+			if got != want {
+				t.Fatalf("unexpected %s output:\n%s\nwant:\n%s", format, got, want)
+			}
+		})
+	}
+}
+
+func TestFormat_EmptyAndUnknown(t *testing.T) {
+	if got, _ := Format(nil, "json"); got != "[]" {
+		t.Fatalf("expected empty JSON array, got %q", got)
+	}
+	if got, _ := Format(nil, "text"); got != "No recorded releases." {
+		t.Fatalf("unexpected empty text output: %q", got)
+	}
+	if _, err := Format(nil, "xml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("expected ErrUnknownFormat, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+// Package history lists the releases recorded in a repository config.
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/config"
+	"golang.org/x/mod/semver"
+)
+
+// Entry is one recorded release.
+type Entry struct {
+	Version string `json:"version"`
+	config.Record
+}
+
+// Prerelease reports whether the entry's version has a prerelease suffix.
+func (e Entry) Prerelease() bool {
+	return semver.Prerelease(canonical(e.Version)) != ""
+}
+
+// Options narrows the entries returned by Filter.
+type Options struct {
+	// Since keeps versions at or after this version.
+	Since string
+	// Limit keeps only the most recent entries when positive.
+	Limit int
+	// Prerelease keeps only prereleases when true and drops them when false.
+	Prerelease *bool
+}
+
+// FromConfig returns the recorded releases in SemVer order. The pending
+// unreleased entry and keys that are not SemVer versions are skipped.
+func FromConfig(cfg config.Config) []Entry {
+	entries := make([]Entry, 0, len(cfg.UsedCodenames))
+	for version, record := range cfg.UsedCodenames {
+		if version == config.UnreleasedKey || !semver.IsValid(canonical(version)) {
+			continue
+		}
+		entries = append(entries, Entry{Version: version, Record: record})
+	}
+	Sort(entries)
+	return entries
+}
+
+// Sort orders entries by SemVer precedence, oldest first.
+func Sort(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if cmp := semver.Compare(canonical(entries[i].Version), canonical(entries[j].Version)); cmp != 0 {
+			return cmp < 0
+		}
+		return entries[i].Version < entries[j].Version
+	})
+}
+
+// Filter applies opts to entries, which must already be sorted.
+func Filter(entries []Entry, opts Options) ([]Entry, error) {
+	since := ""
+	if strings.TrimSpace(opts.Since) != "" {
+		since = canonical(opts.Since)
+		if !semver.IsValid(since) {
+			return nil, fmt.Errorf("invalid --since version %q", opts.Since)
+		}
+	}
+	if opts.Limit < 0 {
+		return nil, fmt.Errorf("--limit must not be negative")
+	}
+
+	filtered := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if since != "" && semver.Compare(canonical(entry.Version), since) < 0 {
+			continue
+		}
+		if opts.Prerelease != nil && entry.Prerelease() != *opts.Prerelease {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+
+	if opts.Limit > 0 && len(filtered) > opts.Limit {
+		filtered = filtered[len(filtered)-opts.Limit:]
+	}
+	return filtered, nil
+}
+
+// canonical adds the "v" prefix golang.org/x/mod/semver expects.
+func canonical(version string) string {
+	version = strings.TrimSpace(version)
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return version
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package history
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/config"
+)
+
+func testConfig() config.Config {
+	cfg := config.Default()
+	for version, codename := range map[string]string{
+		"0.10.0":       "Jade",
+		"0.2.0":        "Bronze",
+		"0.2.0-beta.1": "Amber",
+		"1.0.0-rc.1":   "Coral",
+		"1.0.0":        "Denim",
+		"unreleased":   "Ebony",
+		"latest":       "Flax",
+	} {
+		cfg.UsedCodenames[version] = config.Record{Codename: codename}
+	}
+	return cfg
+}
+
+func versions(entries []Entry) []string {
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry.Version)
+	}
+	return result
+}
+
+func TestFromConfig_SemVerOrder(t *testing.T) {
+	got := versions(FromConfig(testConfig()))
+	want := []string{"0.2.0-beta.1", "0.2.0", "0.10.0", "1.0.0-rc.1", "1.0.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	entries := FromConfig(testConfig())
+	stable, pre := false, true
+
+	cases := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{name: "since", opts: Options{Since: "v0.10.0"}, want: []string{"0.10.0", "1.0.0-rc.1", "1.0.0"}},
+		{name: "limit", opts: Options{Limit: 2}, want: []string{"1.0.0-rc.1", "1.0.0"}},
+		{name: "stable", opts: Options{Prerelease: &stable}, want: []string{"0.2.0", "0.10.0", "1.0.0"}},
+		{name: "prerelease", opts: Options{Prerelease: &pre}, want: []string{"0.2.0-beta.1", "1.0.0-rc.1"}},
+		{name: "combined", opts: Options{Since: "0.2.0", Limit: 1, Prerelease: &stable}, want: []string{"1.0.0"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered, err := Filter(entries, tc.opts)
+			if err != nil {
+				t.Fatalf("filter: %v", err)
+			}
+			if got := versions(filtered); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := Filter(entries, Options{Since: "soon"}); err == nil {
+		t.Fatalf("expected invalid --since to fail")
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/changelog"
+	"github.com/infravillage/tagtastic/internal/config"
+	"github.com/infravillage/tagtastic/internal/git"
+	"golang.org/x/mod/semver"
+)
+
+// Import sources.
+const (
+	SourceConfig    = "config"
+	SourceTags      = "tags"
+	SourceChangelog = "changelog"
+)
+
+// ImportSources lists the sources accepted by history import.
+var ImportSources = []string{SourceTags, SourceChangelog}
+
+// Candidate is a version/codename pair found in an import source.
+type Candidate struct {
+	Version  string
+	Codename string
+	Source   string
+	Commit   string
+}
+
+// Conflict is a version whose sources name different codenames.
+type Conflict struct {
+	Version string
+	// Codenames maps each source to the codename it reports.
+	Codenames map[string]string
+}
+
+func (c Conflict) String() string {
+	sources := make([]string, 0, len(c.Codenames))
+	for source := range c.Codenames {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	parts := make([]string, 0, len(sources))
+	for _, source := range sources {
+		parts = append(parts, fmt.Sprintf("%s=%q", source, c.Codenames[source]))
+	}
+	return fmt.Sprintf("%s: %s", c.Version, strings.Join(parts, " "))
+}
+
+// ImportPlan is the outcome of reconciling import sources with the config.
+type ImportPlan struct {
+	// Added are versions missing from the config on which all sources agree.
+	Added []Entry
+	// Conflicts are versions where sources, or sources and the config,
+	// disagree. They are never written.
+	Conflicts []Conflict
+	// Unchanged counts versions already recorded with the same codename.
+	Unchanged int
+}
+
+// TagCandidates reads version/codename pairs from tag subjects such as
+// "v1.2.3 – Name". Tags without a codename or a SemVer name are skipped.
+func TagCandidates(tags []git.Tag) []Candidate {
+	var candidates []Candidate
+	for _, tag := range tags {
+		codename := git.TagCodename(tag.Subject)
+		if codename == "" || !semver.IsValid(canonical(tag.Name)) {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			Version:  strings.TrimPrefix(tag.Name, "v"),
+			Codename: codename,
+			Source:   SourceTags,
+			Commit:   tag.Commit,
+		})
+	}
+	return candidates
+}
+
+// ChangelogCandidates reads version/codename pairs from release headers.
+func ChangelogCandidates(content string) []Candidate {
+	var candidates []Candidate
+	for _, release := range changelog.ParseReleases(content) {
+		if release.Codename == "" || !semver.IsValid(canonical(release.Version)) {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			Version:  release.Version,
+			Codename: release.Codename,
+			Source:   SourceChangelog,
+		})
+	}
+	return candidates
+}
+
+// PlanImport compares candidates with the releases recorded in cfg.
+// Codenames are compared case-insensitively.
+func PlanImport(cfg config.Config, candidates []Candidate) ImportPlan {
+	recorded := map[string]string{}
+	keys := map[string]string{}
+	for version, record := range cfg.UsedCodenames {
+		if version == config.UnreleasedKey || !semver.IsValid(canonical(version)) {
+			continue
+		}
+		recorded[canonical(version)] = record.Codename
+		keys[canonical(version)] = version
+	}
+
+	type found struct {
+		version   string
+		codenames map[string]string
+		commit    string
+	}
+	byVersion := map[string]*found{}
+	for _, candidate := range candidates {
+		key := canonical(candidate.Version)
+		entry, ok := byVersion[key]
+		if !ok {
+			entry = &found{version: candidate.Version, codenames: map[string]string{}}
+			byVersion[key] = entry
+		}
+		entry.codenames[candidate.Source] = candidate.Codename
+		if candidate.Commit != "" {
+			entry.commit = candidate.Commit
+		}
+	}
+
+	var plan ImportPlan
+	for key, entry := range byVersion {
+		codenames := entry.codenames
+		version := entry.version
+		if existing, ok := recorded[key]; ok {
+			codenames[SourceConfig] = existing
+			version = keys[key]
+		}
+
+		if !agree(codenames) {
+			plan.Conflicts = append(plan.Conflicts, Conflict{Version: version, Codenames: codenames})
+			continue
+		}
+		if _, ok := codenames[SourceConfig]; ok {
+			plan.Unchanged++
+			continue
+		}
+
+		sources := make([]string, 0, len(codenames))
+		codename := ""
+		for source, name := range codenames {
+			sources = append(sources, source)
+			codename = name
+		}
+		sort.Strings(sources)
+		if name, ok := codenames[SourceChangelog]; ok {
+			// Prefer the changelog spelling; tag subjects are often typed by hand.
+			codename = name
+		}
+
+		plan.Added = append(plan.Added, Entry{Version: version, Record: config.Record{
+			Codename: codename,
+			Commit:   entry.commit,
+			Notes:    "imported from " + strings.Join(sources, ", "),
+		}})
+	}
+
+	Sort(plan.Added)
+	sort.Slice(plan.Conflicts, func(i, j int) bool {
+		return semver.Compare(canonical(plan.Conflicts[i].Version), canonical(plan.Conflicts[j].Version)) < 0
+	})
+	return plan
+}
+
+func agree(codenames map[string]string) bool {
+	first, seen := "", false
+	for _, name := range codenames {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !seen {
+			first, seen = name, true
+			continue
+		}
+		if name != first {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package history
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/config"
+	"github.com/infravillage/tagtastic/internal/git"
+)
+
+func TestTagCandidates(t *testing.T) {
+	candidates := TagCandidates([]git.Tag{
+		{Name: "v0.1.0", Commit: "abc", Subject: "v0.1.0 – Almond"},
+		{Name: "v0.2.0", Commit: "def"},
+		{Name: "vnext", Commit: "123", Subject: "vnext – Bronze"},
+	})
+	want := []Candidate{{Version: "0.1.0", Codename: "Almond", Source: SourceTags, Commit: "abc"}}
+	if !reflect.DeepEqual(candidates, want) {
+		t.Fatalf("expected %+v, got %+v", want, candidates)
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	cfg := config.Default()
+	cfg.UsedCodenames["0.1.0"] = config.Record{Codename: "Almond"}
+	cfg.UsedCodenames["0.3.0"] = config.Record{Codename: "Cerise"}
+
+	content := `# Changelog
+
+## [Unreleased]
+
+## [0.4.0] – "Denim" – 2026-03-01
+## [0.3.0] – "Coral" – 2026-02-01
+## [0.2.0] – "Bittersweet" – 2026-01-15
+## [0.1.0] – "Almond" – 2026-01-01
+`
+	candidates := append(TagCandidates([]git.Tag{
+		{Name: "v0.1.0", Commit: "c1", Subject: "v0.1.0 – almond"},
+		{Name: "v0.2.0", Commit: "c2", Subject: "v0.2.0 - bittersweet"},
+		{Name: "v0.4.0", Commit: "c4", Subject: "v0.4.0 – Eggplant"},
+	}), ChangelogCandidates(content)...)
+
+	plan := PlanImport(cfg, candidates)
+
+	wantAdded := []Entry{{Version: "0.2.0", Record: config.Record{
+		Codename: "Bittersweet",
+		Commit:   "c2",
+		Notes:    "imported from changelog, tags",
+	}}}
+	if !reflect.DeepEqual(plan.Added, wantAdded) {
+		t.Fatalf("expected added %+v, got %+v", wantAdded, plan.Added)
+	}
+	if plan.Unchanged != 1 {
+		t.Fatalf("expected one unchanged entry, got %d", plan.Unchanged)
+	}
+
+	var conflicts []string
+	for _, conflict := range plan.Conflicts {
+		conflicts = append(conflicts, conflict.String())
+	}
+	wantConflicts := []string{
+		`0.3.0: changelog="Coral" config="Cerise"`,
+		`0.4.0: changelog="Denim" tags="Eggplant"`,
+	}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Fatalf("expected conflicts %v, got %v", wantConflicts, conflicts)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// MakeFormatter writes a Makefile include with simply expanded variables:
+//
+//	RELEASE_CODENAME := Blue Heron
+type MakeFormatter struct {
+	TextFormatter
+}
+
+func (MakeFormatter) FormatResult(result Result) (string, error) {
+	variables := exportVariables(result)
+	lines := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		lines = append(lines, makeAssignment(variable.Env, variable.Value))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// makeAssignment escapes "$" and "#", keeps leading whitespace and a
+// trailing backslash with an empty $() reference, and uses define for values
+// that span lines.
+func makeAssignment(key, value string) string {
+	escaped := strings.ReplaceAll(value, "$", "$$")
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Sprintf("define %s :=\n%s\nendef", key, escaped)
+	}
+
+	escaped = strings.ReplaceAll(escaped, "#", `\#`)
+	if strings.TrimLeft(escaped, " \t") != escaped {
+		escaped = "$()" + escaped
+	}
+	if strings.HasSuffix(escaped, `\`) {
+		escaped += "$()"
+	}
+	return key + " := " + escaped
+}
+
+// PropertiesFormatter writes Java .properties entries (for Gradle or Maven)
+// using the escaping of java.util.Properties.store:
+//
+//	release.codename=Blue Heron
+type PropertiesFormatter struct {
+	TextFormatter
+}
+
+func (PropertiesFormatter) FormatResult(result Result) (string, error) {
+	variables := exportVariables(result)
+	lines := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		key := strings.ToLower(strings.ReplaceAll(variable.Env, "_", "."))
+		lines = append(lines, propertiesEscape(key, true)+"="+propertiesEscape(variable.Value, false))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// propertiesEscape escapes separators, comment markers and control
+// characters, and writes characters outside printable ASCII as \uXXXX so
+// the file is valid in ISO-8859-1. Spaces are escaped everywhere in keys but
+// only at the start of values.
+func propertiesEscape(value string, key bool) string {
+	var b strings.Builder
+	for i, r := range value {
+		switch {
+		case r == ' ':
+			if key || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case strings.ContainsRune(`\=:#!`, r):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			for _, unit := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&b, `\u%04X`, unit)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// TFVarsFormatter writes Terraform variable definitions (.tfvars, HCL):
+//
+//	release_codename = "Blue Heron"
+type TFVarsFormatter struct {
+	TextFormatter
+}
+
+func (TFVarsFormatter) FormatResult(result Result) (string, error) {
+	variables := exportVariables(result)
+	width := 0
+	for _, variable := range variables {
+		width = max(width, len(variable.Env))
+	}
+
+	// Align the "=" signs the way terraform fmt does.
+	lines := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		lines = append(lines, fmt.Sprintf("%-*s = %s", width, strings.ToLower(variable.Env), hclString(variable.Value)))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// hclString quotes value as an HCL string literal. Template sequences are
+// escaped so "${" and "%{" stay literal.
+func hclString(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	runes := []rune(value)
+	for i, r := range runes {
+		switch {
+		case r == '\\' || r == '"':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && i+1 < len(runes) && runes[i+1] == '{':
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case !unicode.IsPrint(r) && r > 0xFFFF:
+			fmt.Fprintf(&b, `\U%08X`, r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// LDFlagsFormatter writes -X linker flags that set string variables at build
+// time, quoted the way the go command splits -ldflags:
+//
+//	-X 'main.codename=Blue Heron' -X main.codenameSlug=blue-heron
+type LDFlagsFormatter struct {
+	TextFormatter
+	// Package is the import path holding the variables; empty means main.
+	Package string
+}
+
+func (f LDFlagsFormatter) FormatResult(result Result) (string, error) {
+	pkg := f.Package
+	if pkg == "" {
+		pkg = "main"
+	}
+
+	variables := exportVariables(result)
+	flags := make([]string, 0, 2*len(variables))
+	for _, variable := range variables {
+		arg, err := ldflagsQuote(fmt.Sprintf("%s.%s=%s", pkg, variable.Symbol, variable.Value))
+		if err != nil {
+			return "", err
+		}
+		flags = append(flags, "-X", arg)
+	}
+	return strings.Join(flags, " "), nil
+}
+
+// ldflagsQuote follows the go command's quoting rules for flag lists: an
+// argument with whitespace or quotes is wrapped in the quote character it
+// does not contain. There is no escape character, so an argument with both
+// kinds of quotes cannot be written.
+func ldflagsQuote(arg string) (string, error) {
+	var space, single, double bool
+	for _, r := range arg {
+		switch r {
+		case ' ', '\t', '\n', '\r':
+			space = true
+		case '\'':
+			single = true
+		case '"':
+			double = true
+		}
+	}
+
+	switch {
+	case !space && !single && !double:
+		return arg, nil
+	case !single:
+		return "'" + arg + "'", nil
+	case !double:
+		return `"` + arg + `"`, nil
+	default:
+		return "", fmt.Errorf("ldflags: %q contains both single and double quotes", arg)
+	}
+}
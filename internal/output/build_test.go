@@ -0,0 +1,228 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+var roundTripValues = []string{
+	"Blue Heron",
+	"Mac & Cheese $HOME #1 100%",
+	`quote "double" and 'single'`,
+	"  padded",
+	`trail\`,
+	"template ${var} and %{ if }",
+	"key=value: ok!",
+	"Café ☕ 🐦",
+	"multi\nline",
+}
+
+func TestMakeFormatter_RoundTrip(t *testing.T) {
+	if _, err := exec.LookPath("make"); err != nil {
+		t.Skip("make not installed")
+	}
+
+	for _, value := range roundTripValues {
+		include, err := MakeFormatter{}.FormatResult(themedResult(data.CodeName{Name: value, Aliases: []string{"slug"}}, "birds"))
+		if err != nil {
+			t.Fatalf("format %q: %v", value, err)
+		}
+
+		dir := t.TempDir()
+		makefile := filepath.Join(dir, "Makefile")
+		content := include + "\n$(info [$(RELEASE_CODENAME)])\nall: ;@:\n"
+		if err := os.WriteFile(makefile, []byte(content), 0o600); err != nil {
+			t.Fatalf("write makefile: %v", err)
+		}
+
+		output, err := exec.Command("make", "-s", "-f", makefile).CombinedOutput()
+		if err != nil {
+			t.Fatalf("make %q: %v\n%s\n%s", value, err, content, output)
+		}
+		if got := strings.TrimSuffix(string(output), "\n"); got != "["+value+"]" {
+			t.Fatalf("round trip of %q through make gave %q\n%s", value, got, content)
+		}
+	}
+}
+
+func TestMakeFormatter(t *testing.T) {
+	payload, err := MakeFormatter{}.FormatResult(themedResult(data.CodeName{Name: "Blue Heron"}, "birds"))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	want := "RELEASE_CODENAME := Blue Heron\nRELEASE_CODENAME_SLUG := blue-heron\nRELEASE_THEME := birds"
+	if payload != want {
+		t.Fatalf("unexpected payload:\n%s", payload)
+	}
+}
+
+func TestPropertiesFormatter_RoundTrip(t *testing.T) {
+	for _, value := range roundTripValues {
+		payload, err := PropertiesFormatter{}.FormatResult(themedResult(data.CodeName{Name: value, Aliases: []string{"slug"}}, "birds"))
+		if err != nil {
+			t.Fatalf("format %q: %v", value, err)
+		}
+		lines := strings.Split(payload, "\n")
+		if len(lines) != 3 || lines[2] != "release.theme=birds" {
+			t.Fatalf("expected one line per property, got:\n%s", payload)
+		}
+		for _, r := range payload {
+			if r > 0x7e {
+				t.Fatalf("expected ASCII-only output, got:\n%s", payload)
+			}
+		}
+
+		key, raw, _ := strings.Cut(lines[0], "=")
+		if key != "release.codename" {
+			t.Fatalf("unexpected key %q", key)
+		}
+		if got := propertiesUnescape(t, raw); got != value {
+			t.Fatalf("round trip of %q gave %q (%s)", value, got, raw)
+		}
+	}
+}
+
+func TestTFVarsFormatter_RoundTrip(t *testing.T) {
+	payload, err := TFVarsFormatter{}.FormatResult(themedResult(data.CodeName{Name: "Blue Heron"}, "birds"))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	want := "release_codename      = \"Blue Heron\"\nrelease_codename_slug = \"blue-heron\"\nrelease_theme         = \"birds\""
+	if payload != want {
+		t.Fatalf("unexpected payload:\n%s", payload)
+	}
+
+	for _, value := range roundTripValues {
+		literal := hclString(value)
+		// Apart from template escapes, HCL string escapes match JSON.
+		unescaped := strings.NewReplacer("$${", "${", "%%{", "%{").Replace(literal)
+		var got string
+		if err := json.Unmarshal([]byte(unescaped), &got); err != nil {
+			t.Fatalf("decode %s: %v", literal, err)
+		}
+		if got != value {
+			t.Fatalf("round trip of %q gave %q (%s)", value, got, literal)
+		}
+	}
+}
+
+func TestLDFlagsFormatter_RoundTrip(t *testing.T) {
+	payload, err := LDFlagsFormatter{}.FormatResult(themedResult(data.CodeName{Name: "Blue Heron"}, "birds"))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	want := "-X 'main.codename=Blue Heron' -X main.codenameSlug=blue-heron -X main.codenameTheme=birds"
+	if payload != want {
+		t.Fatalf("unexpected payload: %s", payload)
+	}
+
+	for _, value := range roundTripValues {
+		item := data.CodeName{Name: value, Aliases: []string{"slug"}}
+		payload, err := LDFlagsFormatter{Package: "example.com/app/version"}.FormatResult(themedResult(item, "birds"))
+		if strings.Contains(value, `"`) && strings.Contains(value, "'") {
+			if err == nil {
+				t.Fatalf("expected error for %q", value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("format %q: %v", value, err)
+		}
+
+		args := splitLDFlags(t, payload)
+		if len(args) != 6 || args[0] != "-X" || args[1] != "example.com/app/version.codename="+value {
+			t.Fatalf("round trip of %q gave %q", value, args)
+		}
+	}
+}
+
+// propertiesUnescape decodes a value the way java.util.Properties.load does.
+func propertiesUnescape(t *testing.T, raw string) string {
+	t.Helper()
+
+	var units []uint16
+	var b strings.Builder
+	flush := func() {
+		b.WriteString(string(utf16.Decode(units)))
+		units = nil
+	}
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '\\' {
+			flush()
+			b.WriteByte(raw[i])
+			continue
+		}
+		i++
+		switch raw[i] {
+		case 't':
+			flush()
+			b.WriteByte('\t')
+		case 'n':
+			flush()
+			b.WriteByte('\n')
+		case 'r':
+			flush()
+			b.WriteByte('\r')
+		case 'f':
+			flush()
+			b.WriteByte('\f')
+		case 'u':
+			unit, err := strconv.ParseUint(raw[i+1:i+5], 16, 16)
+			if err != nil {
+				t.Fatalf("bad escape in %q: %v", raw, err)
+			}
+			units = append(units, uint16(unit))
+			i += 4
+		default:
+			flush()
+			b.WriteByte(raw[i])
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// splitLDFlags splits a flag list the way the go command does: fields are
+// separated by whitespace and may be wrapped in single or double quotes.
+func splitLDFlags(t *testing.T, flags string) []string {
+	t.Helper()
+
+	var args []string
+	for {
+		flags = strings.TrimLeft(flags, " \t\n\r")
+		if flags == "" {
+			return args
+		}
+		if flags[0] == '\'' || flags[0] == '"' {
+			end := strings.IndexByte(flags[1:], flags[0])
+			if end < 0 {
+				t.Fatalf("unterminated quote in %q", flags)
+			}
+			args = append(args, flags[1:1+end])
+			flags = flags[2+end:]
+			if flags != "" && !strings.ContainsAny(flags[:1], " \t\n\r") {
+				t.Fatalf("quoted argument must be followed by space in %q", flags)
+			}
+			continue
+		}
+		end := strings.IndexAny(flags, " \t\n\r")
+		if end < 0 {
+			end = len(flags)
+		}
+		args = append(args, flags[:end])
+		flags = flags[end:]
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// exportVariable is one value exported by the CI and build-system
+// formatters. Output is the GitHub step output name, Env the environment
+// variable name the other formats derive their keys from, and Symbol the Go
+// variable set through ldflags.
+type exportVariable struct {
+	Output string
+	Env    string
+	Symbol string
+	Value  string
+}
+
+func exportVariables(result Result) []exportVariable {
+	return []exportVariable{
+		{Output: "name", Env: "RELEASE_CODENAME", Symbol: "codename", Value: result.Name},
+		{Output: "slug", Env: "RELEASE_CODENAME_SLUG", Symbol: "codenameSlug", Value: result.Slug},
+		{Output: "theme", Env: "RELEASE_THEME", Symbol: "codenameTheme", Value: result.Theme},
+	}
+}
+
+// GitHubFormatter writes step outputs and environment variables for GitHub
+// Actions. The outputs are appended to $GITHUB_OUTPUT, the variables to
+// $GITHUB_ENV and a summary table to $GITHUB_STEP_SUMMARY when those files
+// are set; the output lines are also returned for the log. Lists and themes
+// are printed as text.
+type GitHubFormatter struct {
+	TextFormatter
+	// Getenv looks up the GitHub file variables; nil uses os.Getenv.
+	Getenv func(string) string
+}
+
+func (f GitHubFormatter) FormatResult(result Result) (string, error) {
+	getenv := f.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+
+	variables := exportVariables(result)
+	outputs := make([]string, 0, len(variables))
+	envs := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		line, err := githubLine(variable.Output, variable.Value)
+		if err != nil {
+			return "", err
+		}
+		outputs = append(outputs, line)
+
+		if line, err = githubLine(variable.Env, variable.Value); err != nil {
+			return "", err
+		}
+		envs = append(envs, line)
+	}
+
+	summary := strings.Join([]string{
+		"### Release codename",
+		"",
+		"| Name | Slug | Theme |",
+		"| --- | --- | --- |",
+		fmt.Sprintf("| %s | %s | %s |", markdownCell(result.Name), markdownCell(result.Slug), markdownCell(result.Theme)),
+	}, "\n")
+
+	for _, file := range []struct{ env, content string }{
+		{env: "GITHUB_OUTPUT", content: strings.Join(outputs, "\n")},
+		{env: "GITHUB_ENV", content: strings.Join(envs, "\n")},
+		{env: "GITHUB_STEP_SUMMARY", content: summary},
+	} {
+		if path := getenv(file.env); path != "" {
+			if err := appendFile(path, file.content+"\n"); err != nil {
+				return "", fmt.Errorf("write $%s: %w", file.env, err)
+			}
+		}
+	}
+
+	return strings.Join(outputs, "\n"), nil
+}
+
+// githubLine renders key=value, switching to the heredoc form with a random
+// delimiter for values that span lines.
+func githubLine(key, value string) (string, error) {
+	if !strings.ContainsAny(value, "\r\n") {
+		return key + "=" + value, nil
+	}
+
+	for {
+		buf := make([]byte, 8)
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		delimiter := "ghadelimiter_" + hex.EncodeToString(buf)
+		if !strings.Contains(value, delimiter) {
+			return fmt.Sprintf("%s<<%s\n%s\n%s", key, delimiter, value, delimiter), nil
+		}
+	}
+}
+
+// GitLabDotenvFormatter writes a dotenv report for artifacts:reports:dotenv.
+// GitLab does not accept multiline values, so line breaks are escaped inside
+// double quotes.
+type GitLabDotenvFormatter struct {
+	TextFormatter
+}
+
+func (GitLabDotenvFormatter) FormatResult(result Result) (string, error) {
+	variables := exportVariables(result)
+	lines := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		lines = append(lines, variable.Env+"="+dotenvValue(variable.Value))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+var dotenvEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "$", `\$`)
+
+func dotenvValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n\"'`\\$#=") {
+		return value
+	}
+	return `"` + dotenvEscaper.Replace(value) + `"`
+}
+
+// AzureFormatter emits ##vso[task.setvariable] logging commands for Azure
+// Pipelines.
+type AzureFormatter struct {
+	TextFormatter
+}
+
+func (AzureFormatter) FormatResult(result Result) (string, error) {
+	variables := exportVariables(result)
+	lines := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		lines = append(lines, fmt.Sprintf("##vso[task.setvariable variable=%s]%s", azureProperty(variable.Env), azureData(variable.Value)))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Escaping used by the Azure Pipelines task library for logging commands.
+var (
+	azureDataEscaper     = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A")
+	azurePropertyEscaper = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", "]", "%5D", ";", "%3B")
+)
+
+func azureData(value string) string {
+	return azureDataEscaper.Replace(value)
+}
+
+func azureProperty(value string) string {
+	return azurePropertyEscaper.Replace(value)
+}
+
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(value)
+}
+
+func appendFile(path, content string) error {
+	// #nosec G304 - the path comes from the CI runner's environment
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(content); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+func TestGitHubFormatter_WritesFiles(t *testing.T) {
+	dir := t.TempDir()
+	env := map[string]string{
+		"GITHUB_OUTPUT":       filepath.Join(dir, "output"),
+		"GITHUB_ENV":          filepath.Join(dir, "env"),
+		"GITHUB_STEP_SUMMARY": filepath.Join(dir, "summary"),
+	}
+	if err := os.WriteFile(env["GITHUB_OUTPUT"], []byte("previous=1\n"), 0o600); err != nil {
+		t.Fatalf("seed output file: %v", err)
+	}
+
+	formatter := GitHubFormatter{Getenv: func(key string) string { return env[key] }}
+	payload, err := formatter.FormatResult(themedResult(data.CodeName{Name: "Blue Heron"}, "birds"))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	if payload != "name=Blue Heron\nslug=blue-heron\ntheme=birds" {
+		t.Fatalf("unexpected payload: %q", payload)
+	}
+
+	expected := map[string]string{
+		"GITHUB_OUTPUT":       "previous=1\nname=Blue Heron\nslug=blue-heron\ntheme=birds\n",
+		"GITHUB_ENV":          "RELEASE_CODENAME=Blue Heron\nRELEASE_CODENAME_SLUG=blue-heron\nRELEASE_THEME=birds\n",
+		"GITHUB_STEP_SUMMARY": "### Release codename\n\n| Name | Slug | Theme |\n| --- | --- | --- |\n| Blue Heron | blue-heron | birds |\n",
+	}
+	for key, want := range expected {
+		got, err := os.ReadFile(env[key])
+		if err != nil {
+			t.Fatalf("read %s: %v", key, err)
+		}
+		if string(got) != want {
+			t.Fatalf("unexpected %s:\n%s", key, got)
+		}
+	}
+}
+
+func TestGitHubFormatter_MultilineUsesDelimiter(t *testing.T) {
+	payload, err := GitHubFormatter{Getenv: func(string) string { return "" }}.FormatResult(themedResult(data.CodeName{Name: "Line\nBreak", Aliases: []string{"line-break"}}, "odd|theme"))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+
+	pattern := regexp.MustCompile(`^name<<(ghadelimiter_[0-9a-f]{16})\nLine\nBreak\n(ghadelimiter_[0-9a-f]{16})\nslug=line-break\ntheme=odd\|theme$`)
+	match := pattern.FindStringSubmatch(payload)
+	if match == nil || match[1] != match[2] {
+		t.Fatalf("expected heredoc form, got:\n%s", payload)
+	}
+}
+
+func TestGitLabDotenvFormatter(t *testing.T) {
+	payload, err := GitLabDotenvFormatter{}.FormatResult(themedResult(data.CodeName{Name: `Mac "n" Cheese`, Aliases: []string{"mac"}}, "crayola_colors"))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	want := `RELEASE_CODENAME="Mac \"n\" Cheese"` + "\n" + "RELEASE_CODENAME_SLUG=mac\nRELEASE_THEME=crayola_colors"
+	if payload != want {
+		t.Fatalf("unexpected payload:\n%s", payload)
+	}
+
+	cases := map[string]string{
+		"Almond":       "Almond",
+		"":             `""`,
+		"two\nlines":   `"two\nlines"`,
+		`back\slash`:   `"back\\slash"`,
+		"cost $5 #tag": `"cost \$5 #tag"`,
+	}
+	for input, want := range cases {
+		if got := dotenvValue(input); got != want {
+			t.Fatalf("dotenvValue(%q): expected %s, got %s", input, want, got)
+		}
+	}
+}
+
+func TestAzureFormatter(t *testing.T) {
+	payload, err := AzureFormatter{}.FormatResult(themedResult(data.CodeName{Name: "100% Pure\nGold]"}, "birds"))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	lines := strings.Split(payload, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected one command per variable, got:\n%s", payload)
+	}
+	if lines[0] != "##vso[task.setvariable variable=RELEASE_CODENAME]100%AZP25 Pure%0AGold]" {
+		t.Fatalf("unexpected name command: %q", lines[0])
+	}
+	if lines[2] != "##vso[task.setvariable variable=RELEASE_THEME]birds" {
+		t.Fatalf("unexpected theme command: %q", lines[2])
+	}
+	if got := azureProperty("a;b]c"); got != "a%3Bb%5Dc" {
+		t.Fatalf("unexpected property escaping: %q", got)
+	}
+}
+
+func TestNewFormatter_CIFormats(t *testing.T) {
+	for _, name := range []string{"github", "gitlab-dotenv", "azure"} {
+		if _, err := NewFormatter(name); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+}
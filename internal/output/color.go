@@ -0,0 +1,97 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+// ColorMode is the color depth used for swatches in text output.
+type ColorMode int
+
+const (
+	ColorNone ColorMode = iota
+	Color256
+	ColorTrue
+)
+
+// swatchWidth is the visible width of a swatch including the space after it.
+const swatchWidth = 3
+
+// DetectColorMode picks the color depth from the environment for output
+// that goes to a terminal: none when NO_COLOR is set or TERM is dumb,
+// truecolor when COLORTERM advertises it and 256 colors otherwise.
+func DetectColorMode(getenv func(string) string) ColorMode {
+	if getenv("NO_COLOR") != "" || getenv("TERM") == "dumb" {
+		return ColorNone
+	}
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorTrue
+	}
+	return Color256
+}
+
+// Swatch returns a two-cell block in color (#RRGGBB) followed by a space, or
+// "" when mode is ColorNone or color is empty or invalid.
+func Swatch(color string, mode ColorMode) string {
+	r, g, b, ok := data.ParseColor(color)
+	if !ok {
+		return ""
+	}
+	switch mode {
+	case ColorTrue:
+		return fmt.Sprintf("\x1b[48;2;%d;%d;%dm  \x1b[0m ", r, g, b)
+	case Color256:
+		return fmt.Sprintf("\x1b[48;5;%dm  \x1b[0m ", xterm256(r, g, b))
+	default:
+		return ""
+	}
+}
+
+// cubeLevels are the channel values of the xterm 6x6x6 color cube.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// xterm256 returns the closest xterm-256 palette index from the color cube
+// or the grayscale ramp.
+func xterm256(r, g, b uint8) int {
+	nearest := func(value int) int {
+		best := 0
+		for i, level := range cubeLevels {
+			if abs(value-level) < abs(value-cubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := nearest(int(r)), nearest(int(g)), nearest(int(b))
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := distance(int(r), int(g), int(b), cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	// The grayscale ramp runs from 8 to 238 in steps of 10.
+	average := (int(r) + int(g) + int(b)) / 3
+	step := min(max((average-8+5)/10, 0), 23)
+	grayLevel := 8 + 10*step
+	if distance(int(r), int(g), int(b), grayLevel, grayLevel, grayLevel) < cubeDist {
+		return 232 + step
+	}
+	return cube
+}
+
+func distance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+func TestDetectColorMode(t *testing.T) {
+	cases := []struct {
+		env  map[string]string
+		want ColorMode
+	}{
+		{env: map[string]string{}, want: Color256},
+		{env: map[string]string{"COLORTERM": "truecolor"}, want: ColorTrue},
+		{env: map[string]string{"COLORTERM": "24bit"}, want: ColorTrue},
+		{env: map[string]string{"COLORTERM": "truecolor", "NO_COLOR": "1"}, want: ColorNone},
+		{env: map[string]string{"TERM": "dumb"}, want: ColorNone},
+	}
+	for _, tc := range cases {
+		if got := DetectColorMode(func(key string) string { return tc.env[key] }); got != tc.want {
+			t.Fatalf("%v: expected %d, got %d", tc.env, tc.want, got)
+		}
+	}
+}
+
+func TestSwatch(t *testing.T) {
+	if got := Swatch("#EFDECD", ColorTrue); got != "\x1b[48;2;239;222;205m  \x1b[0m " {
+		t.Fatalf("unexpected truecolor swatch %q", got)
+	}
+	if got := Swatch("#FF0000", Color256); got != "\x1b[48;5;196m  \x1b[0m " {
+		t.Fatalf("unexpected 256-color swatch %q", got)
+	}
+	if got := Swatch("#EFDECD", ColorNone); got != "" {
+		t.Fatalf("expected no swatch without color, got %q", got)
+	}
+	if got := Swatch("", ColorTrue); got != "" {
+		t.Fatalf("expected no swatch for items without a color, got %q", got)
+	}
+}
+
+func TestXterm256(t *testing.T) {
+	cases := map[[3]uint8]int{
+		{0, 0, 0}:       16,
+		{255, 255, 255}: 231,
+		{128, 128, 128}: 244,
+		{0, 0, 255}:     21,
+	}
+	for rgb, want := range cases {
+		if got := xterm256(rgb[0], rgb[1], rgb[2]); got != want {
+			t.Fatalf("xterm256(%v): expected %d, got %d", rgb, want, got)
+		}
+	}
+}
+
+func TestColorFormatters(t *testing.T) {
+	almond := data.CodeName{Name: "Almond", Aliases: []string{"almond"}, Attributes: data.Attributes{data.AttrHex: "#EFDECD"}}
+
+	name, err := TextFormatter{Colors: ColorTrue}.FormatResult(NewResult(almond))
+	if err != nil || name != Swatch(almond.Attributes[data.AttrHex], ColorTrue)+"Almond" {
+		t.Fatalf("unexpected text result %q (%v)", name, err)
+	}
+
+	payload, err := TableFormatter{TextFormatter: TextFormatter{Colors: Color256}, Width: 40}.FormatList([]data.CodeName{almond, {Name: "Crane"}})
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	lines := strings.Split(payload, "\n")
+	if !strings.HasPrefix(lines[0], "   NAME") || !strings.HasPrefix(lines[1], Swatch(almond.Attributes[data.AttrHex], Color256)+"Almond") || !strings.HasPrefix(lines[2], "   Crane") {
+		t.Fatalf("expected a swatch gutter:\n%q", payload)
+	}
+	for _, line := range lines {
+		visible := strings.Replace(line, Swatch(almond.Attributes[data.AttrHex], Color256), "xxx", 1)
+		if len([]rune(visible)) > 40 {
+			t.Fatalf("line exceeds width: %q", visible)
+		}
+	}
+}
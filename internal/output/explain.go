@@ -0,0 +1,48 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/selection"
+)
+
+// ExplainFormatter is implemented by formatters that render Result.Explain
+// as part of their output. For other formats generate writes the
+// explanation to stderr with FormatExplanation.
+type ExplainFormatter interface {
+	// EmbedsExplanation reports whether the formatter writes Result.Explain
+	// itself, so generate must not also print it to stderr.
+	EmbedsExplanation() bool
+}
+
+// FormatExplanation renders an explanation as human-readable text.
+func FormatExplanation(explain selection.Explanation) string {
+	lines := []string{
+		fmt.Sprintf("theme: %s", explain.Theme),
+		fmt.Sprintf("pool: %d items", explain.PoolSize),
+	}
+
+	for _, stage := range explain.Stages {
+		lines = append(lines, fmt.Sprintf("%s: %d -> %d", stage.Name, stage.Before, stage.After))
+	}
+
+	if len(explain.Excluded) > 0 {
+		lines = append(lines, "excluded:")
+		for _, excluded := range explain.Excluded {
+			lines = append(lines, fmt.Sprintf("  - %s (%s: %s)", excluded.Name, excluded.Stage, excluded.Reason))
+		}
+	}
+
+	lines = append(lines, fmt.Sprintf("seed: %d (from %s)", explain.Seed, explain.SeedSource))
+	if explain.Index >= 0 {
+		lines = append(lines, fmt.Sprintf("index: %d -> %s", explain.Index, explain.Selected))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -7,6 +7,8 @@ package output
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/infravillage/tagtastic/internal/data"
@@ -14,21 +16,53 @@ import (
 
 var ErrUnknownFormat = errors.New("unknown format")
 
+// Formatter renders generate results, theme listings and theme names in one
+// output format.
 type Formatter interface {
-	FormatName(item data.CodeName) (string, error)
+	FormatResult(result Result) (string, error)
 	FormatList(items []data.CodeName) (string, error)
 	FormatThemes(names []string) (string, error)
 }
 
+// registry maps format names, including aliases, to their constructors.
+var registry = map[string]func() Formatter{
+	"text":          func() Formatter { return TextFormatter{} },
+	"table":         func() Formatter { return TableFormatter{} },
+	"wide":          func() Formatter { return TableFormatter{} },
+	"json":          func() Formatter { return JSONFormatter{} },
+	"ndjson":        func() Formatter { return NDJSONFormatter{} },
+	"yaml":          func() Formatter { return YAMLFormatter{} },
+	"yml":           func() Formatter { return YAMLFormatter{} },
+	"shell":         func() Formatter { return ShellFormatter{} },
+	"github":        func() Formatter { return GitHubFormatter{} },
+	"gitlab-dotenv": func() Formatter { return GitLabDotenvFormatter{} },
+	"azure":         func() Formatter { return AzureFormatter{} },
+	"make":          func() Formatter { return MakeFormatter{} },
+	"properties":    func() Formatter { return PropertiesFormatter{} },
+	"tfvars":        func() Formatter { return TFVarsFormatter{} },
+	"hcl":           func() Formatter { return TFVarsFormatter{} },
+	"ldflags":       func() Formatter { return LDFlagsFormatter{} },
+	"template":      func() Formatter { return TemplateFormatter{} },
+}
+
 func NewFormatter(format string) (Formatter, error) {
-	switch strings.ToLower(strings.TrimSpace(format)) {
-	case "", "text":
-		return TextFormatter{}, nil
-	case "json":
-		return JSONFormatter{}, nil
-	case "shell":
-		return ShellFormatter{}, nil
-	default:
-		return nil, ErrUnknownFormat
+	name := strings.ToLower(strings.TrimSpace(format))
+	if name == "" {
+		name = "text"
+	}
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+	return factory(), nil
+}
+
+// Formats returns the registered format names in sorted order.
+func Formats() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
 }
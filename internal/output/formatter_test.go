@@ -6,17 +6,20 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/infravillage/tagtastic/internal/data"
+	"gopkg.in/yaml.v3"
 )
 
 func TestTextFormatter(t *testing.T) {
 	formatter := TextFormatter{}
 	item := data.CodeName{Name: "Almond"}
 
-	name, err := formatter.FormatName(item)
+	name, err := formatter.FormatResult(NewResult(item))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -29,7 +32,7 @@ func TestJSONFormatter(t *testing.T) {
 	formatter := JSONFormatter{}
 	item := data.CodeName{Name: "Almond", Aliases: []string{"almond"}, Description: "Hex #EFDECD"}
 
-	payload, err := formatter.FormatName(item)
+	payload, err := formatter.FormatResult(NewResult(item))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -47,7 +50,7 @@ func TestShellFormatter(t *testing.T) {
 	formatter := ShellFormatter{}
 	item := data.CodeName{Name: "Almond"}
 
-	payload, err := formatter.FormatName(item)
+	payload, err := formatter.FormatResult(NewResult(item))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -66,7 +69,7 @@ func TestShellFormatter_FallbackToSlug(t *testing.T) {
 	formatter := ShellFormatter{}
 	item := data.CodeName{Name: "Blue Heron"}
 
-	payload, err := formatter.FormatName(item)
+	payload, err := formatter.FormatResult(NewResult(item))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -106,3 +109,75 @@ func TestJSONFormatter_ListAndThemes(t *testing.T) {
 		t.Fatalf("unexpected themes output")
 	}
 }
+
+func TestFormats_AllConstructible(t *testing.T) {
+	names := Formats()
+	if len(names) == 0 || names[0] != "azure" {
+		t.Fatalf("expected sorted format names, got %v", names)
+	}
+	for _, name := range names {
+		if _, err := NewFormatter(strings.ToUpper(name)); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestYAMLFormatter(t *testing.T) {
+	formatter := YAMLFormatter{}
+
+	payload, err := formatter.FormatResult(themedResult(data.CodeName{Name: "Blue Heron", Description: "Wading bird"}, "birds"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(payload, "schema_version: 1\nname: Blue Heron\nslug: blue-heron\ntheme: birds\ndescription: Wading bird\n") {
+		t.Fatalf("unexpected yaml payload:\n%s", payload)
+	}
+
+	listPayload, err := formatter.FormatList([]data.CodeName{{Name: "Almond", Aliases: []string{"almond"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded []data.CodeName
+	if err := yaml.Unmarshal([]byte(listPayload), &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0].Name != "Almond" || decoded[0].Aliases[0] != "almond" {
+		t.Fatalf("unexpected list round trip: %+v\n%s", decoded, listPayload)
+	}
+
+	themesPayload, err := formatter.FormatThemes(nil)
+	if err != nil || themesPayload != "[]" {
+		t.Fatalf("expected empty sequence, got %q (%v)", themesPayload, err)
+	}
+}
+
+func TestNDJSONFormatter_Streams(t *testing.T) {
+	formatter := NDJSONFormatter{}
+	items := []data.CodeName{{Name: "Almond"}, {Name: "Antique Brass", Description: "Hex #C88A65"}}
+
+	var buf bytes.Buffer
+	if err := formatter.WriteList(&buf, items); err != nil {
+		t.Fatalf("write list: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per item, got:\n%s", buf.String())
+	}
+	var item data.CodeName
+	if err := json.Unmarshal([]byte(lines[1]), &item); err != nil || item.Description != "Hex #C88A65" {
+		t.Fatalf("unexpected line %q (%v)", lines[1], err)
+	}
+
+	themes, err := formatter.FormatThemes([]string{"birds", "cities"})
+	if err != nil {
+		t.Fatalf("format themes: %v", err)
+	}
+	if themes != "{\"name\":\"birds\"}\n{\"name\":\"cities\"}" {
+		t.Fatalf("unexpected themes: %q", themes)
+	}
+
+	name, err := formatter.FormatResult(themedResult(data.CodeName{Name: "Almond"}, "crayola_colors"))
+	if err != nil || !strings.HasPrefix(name, `{"schema_version":1,"name":"Almond","slug":"almond","theme":"crayola_colors",`) || strings.Contains(name, "\n") {
+		t.Fatalf("unexpected name line %q (%v)", name, err)
+	}
+}
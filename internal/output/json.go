@@ -13,24 +13,16 @@ import (
 
 type JSONFormatter struct{}
 
-func (JSONFormatter) FormatName(item data.CodeName) (string, error) {
-	payload := struct {
-		Name        string   `json:"name"`
-		Aliases     []string `json:"aliases,omitempty"`
-		Description string   `json:"description,omitempty"`
-	}{
-		Name:        item.Name,
-		Aliases:     item.Aliases,
-		Description: item.Description,
-	}
-
-	output, err := json.MarshalIndent(payload, "", "  ")
+func (JSONFormatter) FormatResult(result Result) (string, error) {
+	output, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return "", err
 	}
 	return string(output), nil
 }
 
+func (JSONFormatter) EmbedsExplanation() bool { return true }
+
 func (JSONFormatter) FormatList(items []data.CodeName) (string, error) {
 	output, err := json.MarshalIndent(items, "", "  ")
 	if err != nil {
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+// StreamFormatter is implemented by formatters that can write lists one
+// item at a time instead of building the whole output in memory. list and
+// themes prefer it over FormatList and FormatThemes.
+type StreamFormatter interface {
+	WriteList(w io.Writer, items []data.CodeName) error
+	WriteThemes(w io.Writer, names []string) error
+}
+
+// NDJSONFormatter writes newline-delimited JSON: one compact object per
+// line, for jq -c and log shippers.
+type NDJSONFormatter struct{}
+
+type ndjsonTheme struct {
+	Name string `json:"name"`
+}
+
+func (NDJSONFormatter) FormatResult(result Result) (string, error) {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+func (NDJSONFormatter) EmbedsExplanation() bool { return true }
+
+func (f NDJSONFormatter) FormatList(items []data.CodeName) (string, error) {
+	var buf bytes.Buffer
+	if err := f.WriteList(&buf, items); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func (f NDJSONFormatter) FormatThemes(names []string) (string, error) {
+	var buf bytes.Buffer
+	if err := f.WriteThemes(&buf, names); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func (NDJSONFormatter) WriteList(w io.Writer, items []data.CodeName) error {
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (NDJSONFormatter) WriteThemes(w io.Writer, names []string) error {
+	encoder := json.NewEncoder(w)
+	for _, name := range names {
+		if err := encoder.Encode(ndjsonTheme{Name: name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	_ "embed"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/selection"
+	"github.com/infravillage/tagtastic/internal/slug"
+)
+
+// ResultSchemaVersion is the version of the Result contract. It changes only
+// when a field is removed or its meaning changes; new optional fields keep
+// the version.
+const ResultSchemaVersion = 1
+
+// ResultSchema is the JSON Schema describing the json, ndjson and yaml
+// output of generate (result.schema.json in this package).
+//
+//go:embed result.schema.json
+var ResultSchema []byte
+
+// Result is a generated codename with the context it was generated in. It
+// is what formatters render for generate.
+type Result struct {
+	SchemaVersion int             `json:"schema_version" yaml:"schema_version"`
+	Name          string          `json:"name" yaml:"name"`
+	Slug          string          `json:"slug" yaml:"slug"`
+	Theme         string          `json:"theme" yaml:"theme"`
+	Aliases       []string        `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	Description   string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Attributes    data.Attributes `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	Seed          int64           `json:"seed" yaml:"seed"`
+	SeedSource    string          `json:"seed_source" yaml:"seed_source"`
+	// Version is the release version the codename was recorded for, if any.
+	Version   string                 `json:"version,omitempty" yaml:"version,omitempty"`
+	Generator Generator              `json:"generator" yaml:"generator"`
+	Explain   *selection.Explanation `json:"explain,omitempty" yaml:"explain,omitempty"`
+}
+
+// Generator identifies the tool build that produced a Result.
+type Generator struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version" yaml:"version"`
+	Commit  string `json:"commit,omitempty" yaml:"commit,omitempty"`
+}
+
+// NewResult returns a Result for item with the current schema version and
+// the item's default slug filled in. Callers add the generation context and
+// may replace the slug with one from Slug.
+func NewResult(item data.CodeName) Result {
+	return Result{
+		SchemaVersion: ResultSchemaVersion,
+		Name:          item.Name,
+		Slug:          aliasOrSlug(item),
+		Aliases:       item.Aliases,
+		Description:   item.Description,
+		Attributes:    item.Attributes,
+		Generator:     Generator{Name: "tagtastic"},
+	}
+}
+
+// Slug renders item's slug in the style chosen by opts. It starts from the
+// first alias, which themes use as the canonical slug, or else the name.
+func Slug(item data.CodeName, opts slug.Options) (string, error) {
+	source := item.Name
+	if len(item.Aliases) > 0 && strings.TrimSpace(item.Aliases[0]) != "" {
+		source = item.Aliases[0]
+	}
+	return slug.Make(source, opts)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/selection"
+)
+
+func themedResult(item data.CodeName, theme string) Result {
+	result := NewResult(item)
+	result.Theme = theme
+	return result
+}
+
+func TestNewResult(t *testing.T) {
+	result := NewResult(data.CodeName{Name: "Blue Heron", Description: "Wading bird"})
+	if result.SchemaVersion != ResultSchemaVersion || result.Slug != "blue-heron" || result.Generator.Name != "tagtastic" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+// TestResultSchema keeps result.schema.json in step with the Result struct.
+func TestResultSchema(t *testing.T) {
+	var schema struct {
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(ResultSchema, &schema); err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+
+	var version struct {
+		Const int `json:"const"`
+	}
+	if err := json.Unmarshal(schema.Properties["schema_version"], &version); err != nil || version.Const != ResultSchemaVersion {
+		t.Fatalf("schema_version const %d does not match %d (%v)", version.Const, ResultSchemaVersion, err)
+	}
+
+	full := themedResult(data.CodeName{Name: "Almond", Aliases: []string{"almond"}, Description: "Hex #EFDECD", Attributes: data.Attributes{data.AttrHex: "#EFDECD"}}, "crayola_colors")
+	full.Seed, full.SeedSource, full.Version = 42, selection.SeedFromFlag, "1.2.0"
+	full.Generator.Version, full.Generator.Commit = "1.1.0", "abc123"
+	full.Explain = &selection.Explanation{}
+	payload, err := JSONFormatter{}.FormatResult(full)
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(payload), &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for key := range decoded {
+		if _, ok := schema.Properties[key]; !ok {
+			t.Fatalf("field %q is missing from the schema", key)
+		}
+	}
+	for key := range schema.Properties {
+		if _, ok := decoded[key]; !ok {
+			t.Fatalf("schema property %q is not produced by Result", key)
+		}
+	}
+
+	minimal, err := JSONFormatter{}.FormatResult(NewResult(data.CodeName{Name: "Albatross"}))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	decoded = nil
+	if err := json.Unmarshal([]byte(minimal), &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, key := range schema.Required {
+		if _, ok := decoded[key]; !ok {
+			t.Fatalf("required field %q is omitted", key)
+		}
+	}
+	for key := range decoded {
+		if !slices.Contains(schema.Required, key) {
+			t.Fatalf("optional field %q should be omitted when empty", key)
+		}
+	}
+}
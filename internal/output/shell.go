@@ -14,9 +14,8 @@ import (
 
 type ShellFormatter struct{}
 
-func (ShellFormatter) FormatName(item data.CodeName) (string, error) {
-	value := aliasOrSlug(item)
-	return fmt.Sprintf("RELEASE_CODENAME=%s", value), nil
+func (ShellFormatter) FormatResult(result Result) (string, error) {
+	return fmt.Sprintf("RELEASE_CODENAME=%s", result.Slug), nil
 }
 
 func (ShellFormatter) FormatList(items []data.CodeName) (string, error) {
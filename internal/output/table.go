@@ -0,0 +1,133 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+// TableFormatter lists codenames as aligned columns: name, slug, aliases,
+// the releases that used the name and the description, with a swatch before
+// colored items when Colors is set. Results and theme names are printed as
+// text.
+type TableFormatter struct {
+	TextFormatter
+	// Width is the maximum line width; cells are truncated to fit. Zero
+	// disables truncation.
+	Width int
+	// Versions maps normalized codenames to the release versions that used
+	// them. The USED IN column is shown only when it is not empty.
+	Versions map[string][]string
+}
+
+const (
+	tableGap      = 2
+	tableEllipsis = "…"
+	// tableMinWidth keeps shrunk columns wide enough to stay recognizable.
+	tableMinWidth = 8
+)
+
+func (f TableFormatter) FormatList(items []data.CodeName) (string, error) {
+	header := []string{"NAME", "SLUG", "ALIASES"}
+	if len(f.Versions) > 0 {
+		header = append(header, "USED IN")
+	}
+	header = append(header, "DESCRIPTION")
+
+	rows := [][]string{header}
+	for _, item := range items {
+		itemSlug, err := Slug(item, f.Slugs)
+		if err != nil {
+			return "", err
+		}
+		row := []string{item.Name, itemSlug, dashIfEmpty(strings.Join(item.Aliases, ", "))}
+		if len(f.Versions) > 0 {
+			row = append(row, dashIfEmpty(strings.Join(f.Versions[data.NormalizeName(item.Name)], ", ")))
+		}
+		row = append(row, dashIfEmpty(item.Description))
+		rows = append(rows, row)
+	}
+
+	// Swatches sit in a gutter outside the width calculation, since their
+	// escape sequences take no columns.
+	swatches := make([]string, len(rows))
+	width := f.Width
+	for i, item := range items {
+		swatches[i+1] = Swatch(item.Attributes[data.AttrHex], f.Colors)
+	}
+	if slices.ContainsFunc(swatches, func(swatch string) bool { return swatch != "" }) {
+		for i, swatch := range swatches {
+			if swatch == "" {
+				swatches[i] = strings.Repeat(" ", swatchWidth)
+			}
+		}
+		if width > 0 {
+			width = max(width-swatchWidth, 1)
+		}
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	fitColumns(widths, width)
+
+	lines := make([]string, 0, len(rows))
+	for r, row := range rows {
+		var b strings.Builder
+		b.WriteString(swatches[r])
+		for i, cell := range row {
+			cell = truncateCell(cell, widths[i])
+			b.WriteString(cell)
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+tableGap))
+			}
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// fitColumns shrinks columns until the table fits in width, taking from the
+// last column first since it holds the free-form description. Columns keep
+// tableMinWidth characters unless the table cannot fit otherwise.
+func fitColumns(widths []int, width int) {
+	if width <= 0 {
+		return
+	}
+	total := tableGap * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for _, floor := range []int{tableMinWidth, 1} {
+		for i := len(widths) - 1; i >= 0 && total > width; i-- {
+			shrink := min(total-width, widths[i]-min(widths[i], floor))
+			widths[i] -= shrink
+			total -= shrink
+		}
+	}
+}
+
+func truncateCell(cell string, width int) string {
+	if utf8.RuneCountInString(cell) <= width {
+		return cell
+	}
+	runes := []rune(cell)
+	return strings.TrimRight(string(runes[:width-1]), " ") + tableEllipsis
+}
+
+func dashIfEmpty(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+var tableItems = []data.CodeName{
+	{Name: "Albatross", Aliases: []string{"albatross"}, Description: "Large ocean bird"},
+	{Name: "Blue Heron", Aliases: []string{"blue-heron", "heron"}, Description: "Wading bird"},
+	{Name: "Crane"},
+}
+
+func TestTableFormatter(t *testing.T) {
+	payload, err := TableFormatter{}.FormatList(tableItems)
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	want := strings.Join([]string{
+		"NAME        SLUG        ALIASES            DESCRIPTION",
+		"Albatross   albatross   albatross          Large ocean bird",
+		"Blue Heron  blue-heron  blue-heron, heron  Wading bird",
+		"Crane       crane       -                  -",
+	}, "\n")
+	if payload != want {
+		t.Fatalf("unexpected table:\n%s", payload)
+	}
+}
+
+func TestTableFormatter_VersionsAndWidth(t *testing.T) {
+	formatter := TableFormatter{
+		Width:    60,
+		Versions: map[string][]string{data.NormalizeName("Blue Heron"): {"1.0.0", "2.0.0"}},
+	}
+	payload, err := formatter.FormatList(tableItems)
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	lines := strings.Split(payload, "\n")
+	if !strings.Contains(lines[0], "USED IN") {
+		t.Fatalf("expected a USED IN column:\n%s", payload)
+	}
+	for _, line := range lines {
+		if utf8.RuneCountInString(line) > formatter.Width {
+			t.Fatalf("line exceeds %d columns: %q", formatter.Width, line)
+		}
+	}
+	if !strings.HasSuffix(lines[1], "Large o…") || !strings.Contains(lines[2], "1.0.0,…") {
+		t.Fatalf("expected truncated cells:\n%s", payload)
+	}
+}
+
+func TestTableFormatter_ResultIsText(t *testing.T) {
+	name, err := TableFormatter{}.FormatResult(NewResult(data.CodeName{Name: "Crane"}))
+	if err != nil || name != "Crane" {
+		t.Fatalf("expected plain name, got %q (%v)", name, err)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"text/template"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+// ErrTemplateRequired is returned when the template format is used without
+// a template.
+var ErrTemplateRequired = errors.New("--format template requires --template")
+
+// TemplateFormatter renders each result, or each listed item, with a Go
+// text/template, for example:
+//
+//	{{.Name}} {{.Attributes.hex}}
+//
+// The template sees a Result; listed items have no theme, seed or generator
+// and their .Slug follows Slugs.
+// Missing attributes render as empty strings. Theme names are printed as
+// text.
+type TemplateFormatter struct {
+	TextFormatter
+	Template string
+}
+
+func (f TemplateFormatter) FormatResult(result Result) (string, error) {
+	tmpl, err := f.parse()
+	if err != nil {
+		return "", err
+	}
+	return execute(tmpl, result)
+}
+
+func (f TemplateFormatter) FormatList(items []data.CodeName) (string, error) {
+	tmpl, err := f.parse()
+	if err != nil {
+		return "", err
+	}
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		result := NewResult(item)
+		if result.Slug, err = Slug(item, f.Slugs); err != nil {
+			return "", err
+		}
+		line, err := execute(tmpl, result)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (f TemplateFormatter) parse() (*template.Template, error) {
+	if f.Template == "" {
+		return nil, ErrTemplateRequired
+	}
+	return template.New("output").Option("missingkey=zero").Parse(f.Template)
+}
+
+func execute(tmpl *template.Template, result Result) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, result); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+func TestTemplateFormatter(t *testing.T) {
+	formatter := TemplateFormatter{Template: "{{.Name}} {{.Slug}} {{.Theme}} {{.Attributes.country}}{{.Attributes.missing}}"}
+	kyoto := data.CodeName{Name: "Kyoto", Aliases: []string{"kyoto"}, Attributes: data.Attributes{data.AttrCountry: "Japan"}}
+
+	payload, err := formatter.FormatResult(themedResult(kyoto, "cities"))
+	if err != nil || payload != "Kyoto kyoto cities Japan" {
+		t.Fatalf("unexpected result %q (%v)", payload, err)
+	}
+
+	list, err := formatter.FormatList([]data.CodeName{kyoto, {Name: "Plain"}})
+	if err != nil || list != "Kyoto kyoto  Japan\nPlain plain  " {
+		t.Fatalf("unexpected list %q (%v)", list, err)
+	}
+
+	if _, err := (TemplateFormatter{}).FormatResult(NewResult(kyoto)); !errors.Is(err, ErrTemplateRequired) {
+		t.Fatalf("expected ErrTemplateRequired, got %v", err)
+	}
+	if _, err := (TemplateFormatter{Template: "{{.Name"}).FormatResult(NewResult(kyoto)); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
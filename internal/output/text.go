@@ -9,18 +9,26 @@ import (
 	"strings"
 
 	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/slug"
 )
 
-type TextFormatter struct{}
+type TextFormatter struct {
+	// Colors renders a swatch before the names of colored items.
+	Colors ColorMode
+	// Slugs is the slug style the table and template formats use for
+	// listed items. Other formats list items without slugs, and results
+	// carry the slug the caller made.
+	Slugs slug.Options
+}
 
-func (TextFormatter) FormatName(item data.CodeName) (string, error) {
-	return item.Name, nil
+func (f TextFormatter) FormatResult(result Result) (string, error) {
+	return Swatch(result.Attributes[data.AttrHex], f.Colors) + result.Name, nil
 }
 
-func (TextFormatter) FormatList(items []data.CodeName) (string, error) {
+func (f TextFormatter) FormatList(items []data.CodeName) (string, error) {
 	lines := make([]string, 0, len(items))
 	for _, item := range items {
-		lines = append(lines, item.Name)
+		lines = append(lines, Swatch(item.Attributes[data.AttrHex], f.Colors)+item.Name)
 	}
 	return strings.Join(lines, "\n"), nil
 }
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/data"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFormatter writes YAML with two-space indentation, as used in
+// Kubernetes manifests and Helm values files.
+type YAMLFormatter struct{}
+
+func (YAMLFormatter) FormatResult(result Result) (string, error) {
+	return encodeYAML(result)
+}
+
+func (YAMLFormatter) EmbedsExplanation() bool { return true }
+
+func (YAMLFormatter) FormatList(items []data.CodeName) (string, error) {
+	if items == nil {
+		items = []data.CodeName{}
+	}
+	return encodeYAML(items)
+}
+
+func (YAMLFormatter) FormatThemes(names []string) (string, error) {
+	if names == nil {
+		names = []string{}
+	}
+	return encodeYAML(names)
+}
+
+func encodeYAML(value any) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
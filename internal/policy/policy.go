@@ -0,0 +1,259 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+// Package policy checks proposed codenames against the naming rules in the
+// policy section of .tagtastic.yaml.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/infravillage/tagtastic/internal/config"
+	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/history"
+	"github.com/infravillage/tagtastic/internal/selection"
+	"golang.org/x/mod/semver"
+)
+
+// ErrViolation is wrapped by errors reporting policy violations.
+var ErrViolation = errors.New("naming policy")
+
+// Rule names reported in a Violation. They match the policy config keys.
+const (
+	RuleLetterDistance = "letter_distance"
+	RuleLength         = "length"
+	RuleThemes         = "themes"
+	RuleReuseWindow    = "reuse_window"
+)
+
+// Violation is a rule a proposed codename breaks.
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+}
+
+// Error returns an error wrapping ErrViolation that lists every violation.
+func Error(violations []Violation) error {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+	return fmt.Errorf("%w: %s", ErrViolation, strings.Join(messages, "; "))
+}
+
+// Proposal is a codename proposed for a release. Version may be empty when
+// the release version is not known yet; rules limited to a version pattern
+// are then skipped, and reserved themes are refused.
+type Proposal struct {
+	Name    string
+	Theme   string
+	Version string
+}
+
+// Checker applies a policy to proposals, using the releases recorded in the
+// same config as the previous codenames.
+type Checker struct {
+	policy   config.Policy
+	releases []history.Entry
+}
+
+// New returns a Checker for the policy and releases in cfg.
+func New(cfg config.Config) Checker {
+	return Checker{policy: cfg.Policy, releases: history.FromConfig(cfg)}
+}
+
+// Enabled reports whether any rule is configured.
+func (c Checker) Enabled() bool {
+	return !c.policy.IsZero()
+}
+
+// Check returns every rule the proposal breaks.
+func (c Checker) Check(p Proposal) []Violation {
+	return append(c.CheckTheme(p.Theme, p.Version), c.checkName(p.Name, p.Version)...)
+}
+
+// CheckTheme returns the theme rules broken by using theme for version.
+func (c Checker) CheckTheme(theme, version string) []Violation {
+	var violations []Violation
+	for _, rule := range c.policy.Themes {
+		matched := matches(rule.Versions, version)
+		if len(rule.Allow) > 0 && matched && !contains(rule.Allow, theme) {
+			violations = append(violations, Violation{
+				Rule:    RuleThemes,
+				Message: fmt.Sprintf("theme %q is not allowed for versions %s (allowed: %s)", theme, describe(rule.Versions), strings.Join(rule.Allow, ", ")),
+			})
+		}
+		if contains(rule.Reserve, theme) && !matched {
+			message := fmt.Sprintf("theme %q is reserved for versions %s", theme, describe(rule.Versions))
+			if version == "" {
+				message += "; pass the release version to use it"
+			}
+			violations = append(violations, Violation{Rule: RuleThemes, Message: message})
+		}
+	}
+	return violations
+}
+
+// Filter returns a selection filter that drops names breaking the name
+// rules. Theme rules apply to the whole pool, so check them with
+// CheckTheme first.
+func (c Checker) Filter(version string) selection.Filter {
+	return filter{checker: c, version: version}
+}
+
+type filter struct {
+	checker Checker
+	version string
+}
+
+func (filter) Name() string {
+	return "policy"
+}
+
+func (f filter) Exclude(item data.CodeName) (string, bool) {
+	violations := f.checker.checkName(item.Name, f.version)
+	if len(violations) == 0 {
+		return "", false
+	}
+	return violations[0].String(), true
+}
+
+func (c Checker) checkName(name, version string) []Violation {
+	var violations []Violation
+	previous := c.previous(version)
+
+	if distance := c.policy.LetterDistance; distance > 0 && len(previous) > 0 {
+		last := previous[len(previous)-1]
+		if gap, ok := letterGap(name, last.Codename); ok && gap < distance {
+			message := fmt.Sprintf("%q starts with the same letter as the previous codename %q (%s)", name, last.Codename, last.Version)
+			if gap > 0 {
+				message = fmt.Sprintf("%q starts %d letter(s) from the previous codename %q (%s); at least %d required", name, gap, last.Codename, last.Version, distance)
+			}
+			violations = append(violations, Violation{Rule: RuleLetterDistance, Message: message})
+		}
+	}
+
+	length := utf8.RuneCountInString(strings.TrimSpace(name))
+	for _, rule := range c.policy.Length {
+		if !matches(rule.Versions, version) {
+			continue
+		}
+		switch {
+		case rule.Max > 0 && length > rule.Max:
+			violations = append(violations, Violation{
+				Rule:    RuleLength,
+				Message: fmt.Sprintf("%q has %d characters; versions %s allow at most %d", name, length, describe(rule.Versions), rule.Max),
+			})
+		case rule.Min > 0 && length < rule.Min:
+			violations = append(violations, Violation{
+				Rule:    RuleLength,
+				Message: fmt.Sprintf("%q has %d characters; versions %s need at least %d", name, length, describe(rule.Versions), rule.Min),
+			})
+		}
+	}
+
+	if window := c.policy.ReuseWindow; window > 0 {
+		recent := previous
+		if len(recent) > window {
+			recent = recent[len(recent)-window:]
+		}
+		for i := len(recent) - 1; i >= 0; i-- {
+			if data.NormalizeName(recent[i].Codename) == data.NormalizeName(name) {
+				violations = append(violations, Violation{
+					Rule:    RuleReuseWindow,
+					Message: fmt.Sprintf("%q was used for %s, within the last %d releases", name, recent[i].Version, window),
+				})
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+// previous returns the releases before version, oldest first. With no
+// version every recorded release counts as previous.
+func (c Checker) previous(version string) []history.Entry {
+	if version == "" {
+		return c.releases
+	}
+	target := canonical(version)
+	var entries []history.Entry
+	for _, entry := range c.releases {
+		if semver.Compare(canonical(entry.Version), target) < 0 {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// matches reports whether version matches a glob pattern. An empty pattern
+// or "*" matches every version, including an unknown one.
+func matches(pattern, version string) bool {
+	if pattern == "" || pattern == "*" {
+		return true
+	}
+	if version == "" {
+		return false
+	}
+	ok, err := path.Match(pattern, strings.TrimPrefix(version, "v"))
+	return err == nil && ok
+}
+
+func describe(pattern string) string {
+	if pattern == "" {
+		return `"*"`
+	}
+	return fmt.Sprintf("%q", pattern)
+}
+
+// letterGap returns the alphabet distance between the first letters of two
+// names. ok is false when either name has no leading letter.
+func letterGap(a, b string) (int, bool) {
+	first := func(name string) (rune, bool) {
+		for _, r := range name {
+			if unicode.IsLetter(r) {
+				return unicode.ToLower(r), true
+			}
+		}
+		return 0, false
+	}
+	ra, okA := first(a)
+	rb, okB := first(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	gap := int(ra - rb)
+	if gap < 0 {
+		gap = -gap
+	}
+	return gap, true
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func canonical(version string) string {
+	version = strings.TrimSpace(version)
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return version
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package policy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/config"
+	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/selection"
+)
+
+func testChecker(policy config.Policy) Checker {
+	cfg := config.Default()
+	cfg.Policy = policy
+	for version, codename := range map[string]string{
+		"0.1.0":      "Apricot",
+		"0.2.0":      "Bittersweet",
+		"1.0.0":      "Cerulean",
+		"unreleased": "Denim",
+	} {
+		cfg.UsedCodenames[version] = config.Record{Codename: codename}
+	}
+	return New(cfg)
+}
+
+func rules(violations []Violation) []string {
+	result := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		result = append(result, violation.Rule)
+	}
+	return result
+}
+
+func TestCheck_LetterDistance(t *testing.T) {
+	checker := testChecker(config.Policy{LetterDistance: 2})
+
+	cases := []struct {
+		proposal Proposal
+		want     []string
+	}{
+		{proposal: Proposal{Name: "Cornflower"}, want: []string{RuleLetterDistance}},
+		{proposal: Proposal{Name: "Dandelion"}, want: []string{RuleLetterDistance}},
+		{proposal: Proposal{Name: "Eggplant"}, want: []string{}},
+		// Before 1.0.0 the previous codename is Bittersweet.
+		{proposal: Proposal{Name: "Cornflower", Version: "0.3.0"}, want: []string{RuleLetterDistance}},
+		{proposal: Proposal{Name: "Denim", Version: "0.3.0"}, want: []string{}},
+	}
+	for _, tc := range cases {
+		if got := rules(checker.Check(tc.proposal)); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%+v: expected %v, got %v", tc.proposal, tc.want, got)
+		}
+	}
+}
+
+func TestCheck_LengthAndReuse(t *testing.T) {
+	checker := testChecker(config.Policy{
+		Length: []config.LengthRule{
+			{Versions: "*.0.0", Max: 8},
+			{Min: 4},
+		},
+		ReuseWindow: 2,
+	})
+
+	cases := []struct {
+		proposal Proposal
+		want     []string
+	}{
+		{proposal: Proposal{Name: "Bittersweet", Version: "2.0.0"}, want: []string{RuleLength, RuleReuseWindow}},
+		{proposal: Proposal{Name: "Bittersweet", Version: "2.1.0"}, want: []string{RuleReuseWindow}},
+		{proposal: Proposal{Name: "Apricot", Version: "2.0.0"}, want: []string{}},
+		{proposal: Proposal{Name: "Tan", Version: "2.1.0"}, want: []string{RuleLength}},
+		// The length rule for majors is skipped when the version is unknown.
+		{proposal: Proposal{Name: "Macaroni and Cheese"}, want: []string{}},
+	}
+	for _, tc := range cases {
+		if got := rules(checker.Check(tc.proposal)); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%+v: expected %v, got %v", tc.proposal, tc.want, got)
+		}
+	}
+}
+
+func TestCheckTheme(t *testing.T) {
+	checker := testChecker(config.Policy{
+		Themes: []config.ThemeRule{
+			{Versions: "*-lts*", Reserve: []string{"planets"}},
+			{Versions: "*.0.0", Allow: []string{"birds", "planets"}},
+		},
+	})
+
+	cases := []struct {
+		theme, version string
+		want           int
+	}{
+		{theme: "planets", version: "2.1.0-lts.1", want: 0},
+		{theme: "planets", version: "2.1.0", want: 1},
+		{theme: "planets", version: "", want: 1},
+		{theme: "crayola_colors", version: "2.0.0", want: 1},
+		{theme: "birds", version: "2.0.0", want: 0},
+		{theme: "crayola_colors", version: "", want: 0},
+	}
+	for _, tc := range cases {
+		if got := checker.CheckTheme(tc.theme, tc.version); len(got) != tc.want {
+			t.Fatalf("%s@%s: expected %d violations, got %v", tc.theme, tc.version, tc.want, got)
+		}
+	}
+}
+
+func TestFilter_ExcludesFromSelection(t *testing.T) {
+	checker := testChecker(config.Policy{LetterDistance: 1})
+	theme := &data.Theme{ID: "test", Items: []data.CodeName{{Name: "Canary"}, {Name: "Crane"}, {Name: "Dove"}}}
+
+	result, err := selection.Select(theme, selection.Options{Seed: 1, Filters: []selection.Filter{checker.Filter("")}})
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if result.Item.Name != "Dove" || len(result.Explanation.Excluded) != 2 || result.Explanation.Excluded[0].Stage != "policy" {
+		t.Fatalf("unexpected selection: %+v", result)
+	}
+
+	theme.Items = theme.Items[:2]
+	if _, err := selection.Select(theme, selection.Options{Filters: []selection.Filter{checker.Filter("")}}); !errors.Is(err, selection.ErrPoolExhausted) {
+		t.Fatalf("expected exhausted pool, got %v", err)
+	}
+}
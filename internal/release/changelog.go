@@ -0,0 +1,185 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const unreleasedTemplate = `## [Unreleased]
+
+### Added
+- N/A
+
+### Changed
+- N/A
+
+### Fixed
+- N/A
+`
+
+// updateChangelog turns the [Unreleased] section of the changelog at path
+// into a release section, starts a fresh [Unreleased] section and updates
+// the reference links unless links is zero.
+func updateChangelog(path, version, codename, date string, links Links) error {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	content := string(payload)
+	sections := splitChangelog(content)
+	if sections.unreleased == "" {
+		return errors.New("missing [Unreleased] section in CHANGELOG.md")
+	}
+
+	releaseHeader := fmt.Sprintf("## [%s] – \"%s\" – %s", version, codename, date)
+	newRelease := strings.TrimSpace(sections.unreleased)
+	if newRelease == "" || newRelease == "### Added\n- N/A\n\n### Changed\n- N/A\n\n### Fixed\n- N/A" {
+		newRelease = "### Added\n- Placeholder version entry for this release."
+	}
+
+	releaseBlock := fmt.Sprintf("%s\n\n%s\n\n", releaseHeader, newRelease)
+
+	updated := sections.preamble + unreleasedTemplate + "\n" + releaseBlock + sections.remainder
+	if !links.IsZero() {
+		updated = updateChangelogLinks(updated, version, links)
+	}
+
+	return os.WriteFile(path, []byte(updated), 0o644)
+}
+
+type changelogSections struct {
+	preamble   string
+	unreleased string
+	remainder  string
+}
+
+func splitChangelog(content string) changelogSections {
+	lines := strings.Split(content, "\n")
+	state := "preamble"
+	var preamble, unreleased, remainder []string
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "## [Unreleased]") {
+			state = "unreleased"
+			continue
+		}
+		if strings.HasPrefix(line, "## [") && state == "unreleased" {
+			state = "remainder"
+		}
+
+		switch state {
+		case "preamble":
+			preamble = append(preamble, line)
+		case "unreleased":
+			unreleased = append(unreleased, line)
+		case "remainder":
+			remainder = append(remainder, line)
+		}
+	}
+
+	return changelogSections{
+		preamble:   strings.Join(preamble, "\n"),
+		unreleased: strings.TrimSpace(strings.Join(unreleased, "\n")),
+		remainder:  strings.Join(remainder, "\n"),
+	}
+}
+
+func updateChangelogLinks(content, version string, links Links) string {
+	lines := strings.Split(content, "\n")
+	var output []string
+	var references []string
+	versions := []string{version}
+	inRefs := false
+
+	for _, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "## ["); ok {
+			label, _, _ := strings.Cut(rest, "]")
+			if _, err := parseSemVer(label); err == nil {
+				versions = append(versions, label)
+			}
+		}
+		if strings.HasPrefix(line, "[") && strings.Contains(line, "]: ") {
+			inRefs = true
+			references = append(references, line)
+			continue
+		}
+		if inRefs {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if strings.HasPrefix(line, "#") {
+				inRefs = false
+			}
+		}
+		output = append(output, line)
+	}
+
+	for len(output) > 0 && strings.TrimSpace(output[len(output)-1]) == "" {
+		output = output[:len(output)-1]
+	}
+	output = append(output, "")
+	output = append(output, updateReferenceLines(references, versions, links)...)
+	output = append(output, "")
+
+	return strings.Join(output, "\n")
+}
+
+// updateReferenceLines returns the reference lines for the [Unreleased]
+// section and every version that has a section or a reference line, newest
+// first: a compare link from the previous version, or a release link for the
+// first one. Existing version links are rebuilt, so they follow a change of
+// host; references that are not versions stay at the end.
+func updateReferenceLines(lines []string, released []string, links Links) []string {
+	var others []string
+	for _, line := range lines {
+		label := referenceLabel(line)
+		if strings.EqualFold(label, "Unreleased") {
+			continue
+		}
+		if _, err := parseSemVer(label); err != nil {
+			others = append(others, line)
+			continue
+		}
+		released = append(released, label)
+	}
+
+	parsed := map[string]semVer{}
+	var versions []string
+	for _, v := range released {
+		if _, seen := parsed[v]; !seen {
+			parsed[v], _ = parseSemVer(v)
+			versions = append(versions, v)
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compareSemVer(parsed[versions[i]], parsed[versions[j]]) > 0
+	})
+
+	refs := []string{fmt.Sprintf("[Unreleased]: %s", links.compare("v"+versions[0], "HEAD"))}
+	for i, v := range versions {
+		link := links.release(v)
+		if i+1 < len(versions) {
+			link = links.compare("v"+versions[i+1], "v"+v)
+		}
+		refs = append(refs, fmt.Sprintf("[%s]: %s", v, link))
+	}
+	return append(refs, others...)
+}
+
+// referenceLabel returns the label of a "[label]: url" reference line.
+func referenceLabel(line string) string {
+	label, _, ok := strings.Cut(line, "]: ")
+	if !ok || !strings.HasPrefix(label, "[") {
+		return ""
+	}
+	return label[1:]
+}
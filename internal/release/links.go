@@ -0,0 +1,145 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/config"
+)
+
+// errUnknownHost is returned when the link templates cannot be chosen
+// because the host of the repository URL is not recognized.
+var errUnknownHost = errors.New("unknown repository host")
+
+// hostTemplates are the compare and release link templates of each host.
+var hostTemplates = map[string]struct{ compare, release string }{
+	config.HostGitHub:    {"{url}/compare/{from}...{to}", "{url}/releases/tag/{tag}"},
+	config.HostGitLab:    {"{url}/-/compare/{from}...{to}", "{url}/-/releases/{tag}"},
+	config.HostGitea:     {"{url}/compare/{from}...{to}", "{url}/releases/tag/{tag}"},
+	config.HostForgejo:   {"{url}/compare/{from}...{to}", "{url}/releases/tag/{tag}"},
+	config.HostBitbucket: {"{url}/branches/compare/{to}%0D{from}", "{url}/src/{tag}"},
+}
+
+// Links builds the URLs of the changelog reference lines. The zero value
+// builds none, and the reference lines are left as they are.
+type Links struct {
+	URL     string
+	Compare string
+	Release string
+}
+
+// ResolveLinks returns the links for repo. An empty repository URL is
+// derived from remote, the git origin URL, and an empty host is detected
+// from the URL. Without a URL or templates it returns zero Links.
+func ResolveLinks(repo config.Repository, remote string) (Links, error) {
+	links := Links{URL: strings.TrimSuffix(strings.TrimSpace(repo.URL), "/")}
+	if links.URL == "" {
+		links.URL = webURL(remote)
+	}
+	if links.URL == "" && repo.CompareURL == "" && repo.ReleaseURL == "" {
+		return Links{}, nil
+	}
+
+	host := strings.ToLower(strings.TrimSpace(repo.Host))
+	if host == "" {
+		host = detectHost(links.URL)
+	}
+	switch templates, ok := hostTemplates[host]; {
+	case ok:
+		links.Compare, links.Release = templates.compare, templates.release
+	case host == config.HostCustom, host == "" && repo.CompareURL != "" && repo.ReleaseURL != "":
+		// The templates come from the config below.
+	case host == "":
+		return Links{}, fmt.Errorf("%w: %s", errUnknownHost, links.URL)
+	default:
+		return Links{}, fmt.Errorf("unknown repository.host %q (supported: %s)", repo.Host, strings.Join(config.RepositoryHosts, ", "))
+	}
+
+	if repo.CompareURL != "" {
+		links.Compare = repo.CompareURL
+	}
+	if repo.ReleaseURL != "" {
+		links.Release = repo.ReleaseURL
+	}
+	if links.Compare == "" || links.Release == "" {
+		return Links{}, errors.New("repository.host custom needs repository.compare_url and repository.release_url")
+	}
+	return links, nil
+}
+
+// IsZero reports whether no links are built.
+func (l Links) IsZero() bool {
+	return l.Compare == ""
+}
+
+func (l Links) compare(from, to string) string {
+	return strings.NewReplacer("{url}", l.URL, "{from}", from, "{to}", to).Replace(l.Compare)
+}
+
+func (l Links) release(version string) string {
+	return strings.NewReplacer("{url}", l.URL, "{tag}", "v"+version, "{version}", version).Replace(l.Release)
+}
+
+// webURL turns a git remote URL (https, ssh:// or scp-like user@host:path)
+// into the https address of the repository, or "" when remote is empty or
+// a local path.
+func webURL(remote string) string {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return ""
+	}
+	if !strings.Contains(remote, "://") {
+		// scp-like syntax: git@host:owner/project.git
+		at := strings.Index(remote, "@")
+		colon := strings.Index(remote, ":")
+		if colon <= at+1 {
+			return ""
+		}
+		remote = "ssh://" + remote[:colon] + "/" + remote[colon+1:]
+	}
+
+	parsed, err := url.Parse(remote)
+	if err != nil || parsed.Hostname() == "" {
+		return ""
+	}
+	host := parsed.Hostname()
+	if parsed.Scheme == "http" || parsed.Scheme == "https" {
+		host = parsed.Host
+	}
+	path := strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	scheme := "https"
+	if parsed.Scheme == "http" {
+		scheme = "http"
+	}
+	return scheme + "://" + host + "/" + path
+}
+
+// detectHost guesses the hosting software from the URL's host name.
+func detectHost(repoURL string) string {
+	parsed, err := url.Parse(repoURL)
+	if err != nil {
+		return ""
+	}
+	name := strings.ToLower(parsed.Hostname())
+	switch {
+	case strings.Contains(name, "github"):
+		return config.HostGitHub
+	case strings.Contains(name, "gitlab"):
+		return config.HostGitLab
+	case strings.Contains(name, "bitbucket"):
+		return config.HostBitbucket
+	case name == "codeberg.org" || strings.Contains(name, "forgejo"):
+		return config.HostForgejo
+	case strings.Contains(name, "gitea"):
+		return config.HostGitea
+	default:
+		return ""
+	}
+}
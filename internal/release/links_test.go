@@ -0,0 +1,133 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/config"
+)
+
+func TestWebURL(t *testing.T) {
+	cases := map[string]string{
+		"":                       "",
+		"/srv/git/tagtastic.git": "",
+		"git@github.com:infravillage/tagtastic.git":               "https://github.com/infravillage/tagtastic",
+		"ssh://git@gitlab.example.com:2222/group/sub/project.git": "https://gitlab.example.com/group/sub/project",
+		"https://codeberg.org/infravillage/tagtastic.git/":        "https://codeberg.org/infravillage/tagtastic",
+		"http://gitea.local:3000/team/tagtastic":                  "http://gitea.local:3000/team/tagtastic",
+	}
+	for remote, want := range cases {
+		if got := webURL(remote); got != want {
+			t.Fatalf("webURL(%q) = %q, want %q", remote, got, want)
+		}
+	}
+}
+
+func TestResolveLinks(t *testing.T) {
+	cases := []struct {
+		name        string
+		repo        config.Repository
+		remote      string
+		compare     string
+		releaseLink string
+	}{
+		{
+			name:        "github remote",
+			remote:      "git@github.com:infravillage/tagtastic.git",
+			compare:     "https://github.com/infravillage/tagtastic/compare/v0.1.0...v0.2.0",
+			releaseLink: "https://github.com/infravillage/tagtastic/releases/tag/v0.2.0",
+		},
+		{
+			name:        "gitlab url overrides remote",
+			repo:        config.Repository{URL: "https://gitlab.com/infravillage/tagtastic/"},
+			remote:      "git@github.com:infravillage/tagtastic.git",
+			compare:     "https://gitlab.com/infravillage/tagtastic/-/compare/v0.1.0...v0.2.0",
+			releaseLink: "https://gitlab.com/infravillage/tagtastic/-/releases/v0.2.0",
+		},
+		{
+			name:        "codeberg is forgejo",
+			remote:      "https://codeberg.org/infravillage/tagtastic.git",
+			compare:     "https://codeberg.org/infravillage/tagtastic/compare/v0.1.0...v0.2.0",
+			releaseLink: "https://codeberg.org/infravillage/tagtastic/releases/tag/v0.2.0",
+		},
+		{
+			name:        "explicit host for a self-hosted gitea",
+			repo:        config.Repository{Host: config.HostGitea},
+			remote:      "git@git.example.com:team/tagtastic.git",
+			compare:     "https://git.example.com/team/tagtastic/compare/v0.1.0...v0.2.0",
+			releaseLink: "https://git.example.com/team/tagtastic/releases/tag/v0.2.0",
+		},
+		{
+			name:        "bitbucket",
+			remote:      "git@bitbucket.org:infravillage/tagtastic.git",
+			compare:     "https://bitbucket.org/infravillage/tagtastic/branches/compare/v0.2.0%0Dv0.1.0",
+			releaseLink: "https://bitbucket.org/infravillage/tagtastic/src/v0.2.0",
+		},
+		{
+			name: "custom templates",
+			repo: config.Repository{
+				URL:        "https://code.example.com/tagtastic",
+				Host:       config.HostCustom,
+				CompareURL: "{url}/diff?from={from}&to={to}",
+				ReleaseURL: "https://downloads.example.com/tagtastic/{version}",
+			},
+			compare:     "https://code.example.com/tagtastic/diff?from=v0.1.0&to=v0.2.0",
+			releaseLink: "https://downloads.example.com/tagtastic/0.2.0",
+		},
+	}
+	for _, tc := range cases {
+		links, err := ResolveLinks(tc.repo, tc.remote)
+		if err != nil {
+			t.Fatalf("%s: ResolveLinks failed: %v", tc.name, err)
+		}
+		if got := links.compare("v0.1.0", "v0.2.0"); got != tc.compare {
+			t.Fatalf("%s: compare = %q, want %q", tc.name, got, tc.compare)
+		}
+		if got := links.release("0.2.0"); got != tc.releaseLink {
+			t.Fatalf("%s: release = %q, want %q", tc.name, got, tc.releaseLink)
+		}
+	}
+}
+
+func TestResolveLinks_Errors(t *testing.T) {
+	if links, err := ResolveLinks(config.Repository{}, ""); err != nil || !links.IsZero() {
+		t.Fatalf("expected zero links without a URL, got %+v, %v", links, err)
+	}
+	if _, err := ResolveLinks(config.Repository{}, "git@git.example.com:team/tagtastic.git"); !errors.Is(err, errUnknownHost) {
+		t.Fatalf("expected unknown host error, got %v", err)
+	}
+	if _, err := ResolveLinks(config.Repository{URL: "https://git.example.com/x", Host: "sourcehut"}, ""); err == nil || !strings.Contains(err.Error(), "unknown repository.host") {
+		t.Fatalf("expected unsupported host error, got %v", err)
+	}
+	if _, err := ResolveLinks(config.Repository{URL: "https://git.example.com/x", Host: config.HostCustom}, ""); err == nil || !strings.Contains(err.Error(), "compare_url") {
+		t.Fatalf("expected missing template error, got %v", err)
+	}
+}
+
+func TestUpdateChangelogLinks_RewritesOtherHosts(t *testing.T) {
+	content := "# Changelog\n\n## [Unreleased]\n\n## [0.3.0] – \"C\" – 2026-03-01\n\n## [0.2.0] – \"B\" – 2026-02-01\n\n## [0.1.0] – \"A\" – 2026-01-01\n\n" +
+		"[Unreleased]: https://github.com/old/tagtastic/compare/v0.2.0...HEAD\n" +
+		"[0.2.0]: https://github.com/old/tagtastic/compare/vUnreleased...v0.2.0\n" +
+		"[0.1.0]: https://github.com/old/tagtastic/releases/tag/v0.1.0\n" +
+		"[keep a changelog]: https://keepachangelog.com\n"
+	links, err := ResolveLinks(config.Repository{URL: "https://gitlab.com/infravillage/tagtastic"}, "")
+	if err != nil {
+		t.Fatalf("ResolveLinks failed: %v", err)
+	}
+
+	got := updateChangelogLinks(content, "0.3.0", links)
+	want := "\n\n[Unreleased]: https://gitlab.com/infravillage/tagtastic/-/compare/v0.3.0...HEAD\n" +
+		"[0.3.0]: https://gitlab.com/infravillage/tagtastic/-/compare/v0.2.0...v0.3.0\n" +
+		"[0.2.0]: https://gitlab.com/infravillage/tagtastic/-/compare/v0.1.0...v0.2.0\n" +
+		"[0.1.0]: https://gitlab.com/infravillage/tagtastic/-/releases/v0.1.0\n" +
+		"[keep a changelog]: https://keepachangelog.com\n"
+	if !strings.HasSuffix(got, want) || strings.Count(got, "[Unreleased]:") != 1 {
+		t.Fatalf("unexpected references:\n%s", got)
+	}
+}
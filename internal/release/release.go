@@ -0,0 +1,459 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+// Package release prepares a release: it resolves the next version and
+// codename, moves the [Unreleased] changelog section under the new version,
+// writes VERSION, records the codename in the repository config and tags
+// the release in git.
+package release
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/infravillage/tagtastic/internal/changelog"
+	"github.com/infravillage/tagtastic/internal/config"
+	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/git"
+	"github.com/infravillage/tagtastic/internal/policy"
+	"github.com/infravillage/tagtastic/internal/selection"
+)
+
+// DefaultTheme is the theme codenames are picked from when Options.Theme
+// is empty.
+const DefaultTheme = "crayola_colors"
+
+// Errors from Prepare and Apply wrap one of these so callers can tell
+// invalid options, git failures and bad repository settings apart. The
+// wrapped error's message is kept as is.
+var (
+	ErrUsage      = errors.New("invalid release options")
+	ErrGit        = errors.New("git failed")
+	ErrRepository = errors.New("invalid repository settings")
+)
+
+// kindError tags err with one of the sentinels above.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e kindError) Error() string   { return e.err.Error() }
+func (e kindError) Unwrap() []error { return []error{e.kind, e.err} }
+
+func usageErrorf(format string, args ...any) error {
+	return kindError{kind: ErrUsage, err: fmt.Errorf(format, args...)}
+}
+
+// Options describe the release to prepare.
+type Options struct {
+	// Dir is the repository root. CHANGELOG.md and VERSION are read there
+	// and git runs in it.
+	Dir string
+	// Version is the release version; leave it empty to use Bump.
+	Version string
+	// Bump increments the latest tagged or VERSION version: major, minor
+	// or patch.
+	Bump string
+	// Pre and PreNum turn the version into a prerelease such as 1.2.0-rc.1;
+	// PreNum 0 picks the next number not yet tagged.
+	Pre    string
+	PreNum int
+	// Codename overrides the picked codename.
+	Codename string
+	// Themes and Theme supply the codename when Codename is empty and no
+	// codename is pending under "unreleased" in ConfigPath. Names
+	// used in CHANGELOG.md or recorded in ConfigPath are skipped, and the
+	// naming policy in ConfigPath applies as it does for generate.
+	Themes data.ThemeRepository
+	Theme  string
+	// Random draws the codename with generate's seeded picker instead of
+	// taking the first available one in theme order. Seed 0 uses the time.
+	Random bool
+	Seed   int64
+	// Date is the release date (YYYY-MM-DD); empty uses today.
+	Date string
+	// ConfigPath is the config file the release is recorded in and the
+	// used codenames and policy are read from.
+	ConfigPath string
+	// Note is stored with the release record.
+	Note string
+	// Commit commits CHANGELOG.md and VERSION before tagging.
+	Commit bool
+	// NoConfigUpdate skips recording the release in ConfigPath.
+	NoConfigUpdate bool
+
+	// Now and GitInfo supply the audit details of the release record.
+	Now     func() time.Time
+	GitInfo func(dir string) git.Info
+	// Output receives the output of git commands.
+	Output io.Writer
+	// Warn reports warnings found while reading the config.
+	Warn func(path string, warnings []config.Warning)
+	// Logger receives diagnostics; nil discards them.
+	Logger *slog.Logger
+}
+
+func (o Options) withDefaults() Options {
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	if o.GitInfo == nil {
+		o.GitInfo = git.Describe
+	}
+	if o.Output == nil {
+		o.Output = io.Discard
+	}
+	if o.Warn == nil {
+		o.Warn = func(string, []config.Warning) {}
+	}
+	if o.Logger == nil {
+		o.Logger = slog.New(slog.DiscardHandler)
+	}
+	return o
+}
+
+// Plan is a release resolved from Options, ready to apply.
+type Plan struct {
+	Version  string
+	Codename string
+	// Theme is the theme the codename was picked from, or empty when it was
+	// given with Options.Codename.
+	Theme string
+	// Seed is the seed the codename was drawn with, or 0 in theme order.
+	Seed int64
+	Date string
+	// Links builds the changelog reference links; zero leaves them as they
+	// are.
+	Links Links
+	// Promote reports that the codename is the pending unreleased record,
+	// which Apply moves to the version as promote does.
+	Promote bool
+}
+
+// Prepare resolves the version, codename and date of the release without
+// changing anything. Invalid options are reported as ErrUsage errors.
+func Prepare(opts Options) (Plan, error) {
+	opts = opts.withDefaults()
+	version := strings.TrimSpace(opts.Version)
+	bump := strings.TrimSpace(opts.Bump)
+	pre := strings.TrimSpace(opts.Pre)
+
+	if version == "" && bump == "" {
+		return Plan{}, usageErrorf("version is required (or use --bump)")
+	}
+	if version != "" && bump != "" {
+		return Plan{}, usageErrorf("use either a version argument or --bump, not both")
+	}
+
+	tags, err := listTags(opts.Dir)
+	if err != nil {
+		return Plan{}, kindError{kind: ErrGit, err: err}
+	}
+	latest, err := latestVersion(opts.Dir, tags)
+	if err != nil {
+		return Plan{}, kindError{kind: ErrGit, err: err}
+	}
+	opts.Logger.Debug("latest release version", "version", latest, "tags", len(tags))
+
+	if bump != "" {
+		if latest == "" {
+			return Plan{}, usageErrorf("unable to auto-bump version: no existing version tags or VERSION file found")
+		}
+		if version, err = bumpVersion(latest, bump); err != nil {
+			return Plan{}, kindError{kind: ErrUsage, err: err}
+		}
+	}
+
+	if pre == "" && opts.PreNum > 0 {
+		return Plan{}, usageErrorf("use --pre when providing --pre-num")
+	}
+	if pre != "" {
+		if _, err := parseSemVer(version); err != nil {
+			return Plan{}, kindError{kind: ErrUsage, err: err}
+		}
+		if version, err = resolvePreReleaseVersion(version, pre, opts.PreNum, tags); err != nil {
+			return Plan{}, kindError{kind: ErrUsage, err: err}
+		}
+	}
+
+	if err := ensureSemVerForward(version, latest); err != nil {
+		return Plan{}, kindError{kind: ErrUsage, err: err}
+	}
+
+	plan := Plan{Version: version, Codename: strings.TrimSpace(opts.Codename), Date: strings.TrimSpace(opts.Date)}
+	if plan.Date == "" {
+		plan.Date = opts.Now().Format(time.DateOnly)
+	} else if _, err := time.Parse(time.DateOnly, plan.Date); err != nil {
+		return Plan{}, usageErrorf("invalid --date %q: expected YYYY-MM-DD", plan.Date)
+	}
+
+	var cfg config.Config
+	if opts.ConfigPath != "" {
+		if cfg, err = config.Load(opts.ConfigPath); err != nil {
+			return Plan{}, err
+		}
+	}
+	if !opts.NoConfigUpdate {
+		if err := cfg.EnsureUnrecorded(version); err != nil {
+			return Plan{}, err
+		}
+	}
+
+	if pending, ok := cfg.UsedCodenames[config.UnreleasedKey]; ok && plan.Codename == "" && strings.TrimSpace(pending.Codename) != "" {
+		plan.Codename, plan.Theme, plan.Seed, plan.Promote = pending.Codename, pending.Theme, pending.Seed, true
+		opts.Logger.Debug("using pending unreleased codename", "codename", pending.Codename)
+	}
+	if plan.Codename == "" {
+		if err := pickCodename(opts, cfg, &plan); err != nil {
+			return Plan{}, err
+		}
+	}
+
+	plan.Links, err = ResolveLinks(cfg.Repository, git.RemoteURL(opts.Dir, "origin"))
+	switch {
+	case errors.Is(err, errUnknownHost):
+		opts.Warn(opts.ConfigPath, []config.Warning{{Key: "repository", Message: err.Error() + "; set repository.host to update changelog links"}})
+	case err != nil:
+		return Plan{}, kindError{kind: ErrRepository, err: err}
+	case plan.Links.IsZero():
+		opts.Warn(opts.ConfigPath, []config.Warning{{Key: "repository.url", Message: "not set and no origin remote; changelog links are left unchanged"}})
+	}
+	opts.Logger.Debug("release plan", "version", plan.Version, "codename", plan.Codename, "date", plan.Date)
+	return plan, nil
+}
+
+// Apply carries out the plan: it updates CHANGELOG.md and VERSION, commits
+// them when Options.Commit is set, records the release in the config and
+// creates an annotated v<version> tag.
+func Apply(opts Options, plan Plan) error {
+	opts = opts.withDefaults()
+
+	if err := updateChangelog(filepath.Join(opts.Dir, "CHANGELOG.md"), plan.Version, plan.Codename, plan.Date, plan.Links); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(opts.Dir, "VERSION"), []byte(plan.Version+"\n"), 0o644); err != nil {
+		return err
+	}
+
+	if opts.Commit {
+		if err := commitRelease(opts, plan); err != nil {
+			return kindError{kind: ErrGit, err: err}
+		}
+	}
+
+	if !opts.NoConfigUpdate {
+		info := opts.GitInfo(opts.Dir)
+		record := config.Record{
+			Codename:   plan.Codename,
+			Theme:      plan.Theme,
+			Seed:       plan.Seed,
+			RecordedAt: opts.Now().UTC().Truncate(time.Second),
+			Commit:     info.Commit,
+			RecordedBy: info.UserEmail,
+			Notes:      strings.TrimSpace(opts.Note),
+		}
+		if err := updateRepoConfig(opts, plan.Version, record, plan.Promote); err != nil {
+			return err
+		}
+	}
+
+	if err := createTag(opts, plan); err != nil {
+		return kindError{kind: ErrGit, err: err}
+	}
+	return nil
+}
+
+// pickCodename fills in the plan's codename and theme from the theme
+// repository, skipping names already used and names the policy rejects.
+func pickCodename(opts Options, cfg config.Config, plan *Plan) error {
+	if opts.Themes == nil {
+		return errors.New("no theme repository to pick a codename from")
+	}
+	name := strings.TrimSpace(opts.Theme)
+	if name == "" {
+		name = DefaultTheme
+	}
+	theme, err := opts.Themes.GetThemeByName(name)
+	if err != nil {
+		return err
+	}
+
+	used := usedCodenames(cfg, filepath.Join(opts.Dir, "CHANGELOG.md"))
+	filters := []selection.Filter{selection.ExcludeNames(used)}
+	if checker := policy.New(cfg); checker.Enabled() {
+		if violations := checker.CheckTheme(theme.ID, plan.Version); len(violations) > 0 {
+			return policy.Error(violations)
+		}
+		filters = append(filters, checker.Filter(plan.Version))
+	}
+	opts.Logger.Debug("picking release codename", "theme", theme.ID, "used", len(used), "random", opts.Random || opts.Seed != 0)
+
+	plan.Theme = theme.ID
+	if !opts.Random && opts.Seed == 0 {
+		pool := selection.Apply(theme.Items, filters...)
+		if len(pool) == 0 {
+			return selection.ErrPoolExhausted
+		}
+		plan.Codename = pool[0].Name
+		return nil
+	}
+
+	seed, source := opts.Seed, selection.SeedFromFlag
+	if seed == 0 {
+		seed, source = opts.Now().UnixNano(), selection.SeedFromTime
+	}
+	result, err := selection.Select(theme, selection.Options{Seed: seed, SeedSource: source, Filters: filters, Logger: opts.Logger})
+	if err != nil {
+		return err
+	}
+	plan.Codename, plan.Seed = result.Item.Name, seed
+	return nil
+}
+
+// usedCodenames returns the codenames recorded in cfg and in the release
+// headers of the changelog at path.
+func usedCodenames(cfg config.Config, path string) []string {
+	var used []string
+	for _, record := range cfg.UsedCodenames {
+		used = append(used, record.Codename)
+	}
+	if content, err := os.ReadFile(path); err == nil {
+		for name := range changelog.Codenames(string(content)) {
+			used = append(used, name)
+		}
+	}
+	return used
+}
+
+// latestVersion returns the highest tagged version, or else the version in
+// the VERSION file, or "" when there is neither.
+func latestVersion(dir string, tags []string) (string, error) {
+	if latest := latestTagVersion(tags); latest != "" {
+		return latest, nil
+	}
+
+	payload, err := os.ReadFile(filepath.Join(dir, "VERSION"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	version := strings.TrimSpace(string(payload))
+	if version == "" {
+		return "", nil
+	}
+	if _, err := parseSemVer(version); err != nil {
+		return "", fmt.Errorf("invalid VERSION file: %w", err)
+	}
+	return version, nil
+}
+
+func isRepository(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+func listTags(dir string) ([]string, error) {
+	if !isRepository(dir) {
+		return []string{}, nil
+	}
+	tags, err := git.Tags(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names, nil
+}
+
+func createTag(opts Options, plan Plan) error {
+	if !isRepository(opts.Dir) {
+		return errors.New("git repository not found")
+	}
+	message := fmt.Sprintf("v%s – %s", plan.Version, plan.Codename)
+	return runGit(opts, "tag", "-a", "v"+plan.Version, "-m", message)
+}
+
+func commitRelease(opts Options, plan Plan) error {
+	if err := runGit(opts, "add", "CHANGELOG.md", "VERSION"); err != nil {
+		return err
+	}
+	message := fmt.Sprintf("chore: prepare release v%s (%s)", plan.Version, plan.Codename)
+	return runGit(opts, "commit", "-m", message)
+}
+
+func runGit(opts Options, args ...string) error {
+	// #nosec G204 - version is validated as SemVer, codename from controlled data
+	cmd := exec.Command("git", append([]string{"-C", opts.Dir}, args...)...)
+	cmd.Stdout = opts.Output
+	cmd.Stderr = opts.Output
+	return cmd.Run()
+}
+
+// updateRepoConfig records the release in the repo config, editing the file
+// in place. With promote, the pending unreleased record is moved to version
+// instead, keeping what generate --record stored.
+func updateRepoConfig(opts Options, version string, record config.Record, promote bool) error {
+	path := opts.ConfigPath
+	cfg, warnings, err := config.LoadWithWarnings(path)
+	if err != nil {
+		return err
+	}
+	opts.Warn(path, warnings)
+
+	doc, err := config.ReadDocument(path)
+	if err != nil {
+		return err
+	}
+	if cfg.DefaultTheme == "" {
+		theme := record.Theme
+		if theme == "" {
+			theme = DefaultTheme
+		}
+		if err := doc.Set([]string{"default_theme"}, theme); err != nil {
+			return err
+		}
+	}
+	if cfg.DefaultFormat == "" {
+		if err := doc.Set([]string{"default_format"}, "text"); err != nil {
+			return err
+		}
+	}
+	if err := cfg.EnsureUnrecorded(version); err != nil {
+		return err
+	}
+	if pending, ok := cfg.UsedCodenames[config.UnreleasedKey]; promote && ok {
+		if record.Notes != "" {
+			pending.Notes = record.Notes
+		}
+		record = pending
+	}
+	if err := doc.SetRecord(version, record); err != nil {
+		return err
+	}
+	if promote {
+		if _, err := doc.Delete([]string{"used_codenames", config.UnreleasedKey}); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, doc.Bytes(), 0o600)
+}
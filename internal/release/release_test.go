@@ -0,0 +1,353 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package release
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/infravillage/tagtastic/internal/config"
+	"github.com/infravillage/tagtastic/internal/data"
+	"github.com/infravillage/tagtastic/internal/git"
+	"github.com/infravillage/tagtastic/internal/policy"
+)
+
+func TestUpdateRepoConfigCreatesFile(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".tagtastic.yaml")
+
+	if err := updateRepoConfig(Options{ConfigPath: path}.withDefaults(), "0.1.0-beta.1", config.Record{Codename: "Almond"}, false); err != nil {
+		t.Fatalf("updateRepoConfig failed: %v", err)
+	}
+
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	content := string(payload)
+
+	if !strings.Contains(content, "default_theme: crayola_colors") {
+		t.Fatalf("expected default_theme to be set")
+	}
+	if !strings.Contains(content, "0.1.0-beta.1: Almond") {
+		t.Fatalf("expected codename to be recorded")
+	}
+}
+
+func TestUpdateRepoConfigPreservesComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	original := "# release history\nschema_version: 1\ndefault_theme: birds # pinned\ndefault_format: text\nused_codenames:\n  0.1.0: Albatross\n"
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	if err := updateRepoConfig(Options{ConfigPath: path}.withDefaults(), "0.2.0", config.Record{Codename: "Bittern"}, false); err != nil {
+		t.Fatalf("updateRepoConfig failed: %v", err)
+	}
+
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	expected := original + "  0.2.0: Bittern\n"
+	if string(payload) != expected {
+		t.Fatalf("expected only the new entry to be added, got:\n%s", payload)
+	}
+}
+
+func TestUpdateRepoConfigWritesRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	if err := os.WriteFile(path, []byte("schema_version: 1\ndefault_theme: crayola_colors\ndefault_format: text\nused_codenames:\n    0.1.0: Almond\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	record := config.Record{
+		Codename:   "Apricot",
+		Theme:      "crayola_colors",
+		RecordedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Commit:     "abc123",
+		Notes:      "first beta",
+	}
+	if err := updateRepoConfig(Options{ConfigPath: path}.withDefaults(), "0.2.0", record, false); err != nil {
+		t.Fatalf("updateRepoConfig failed: %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.SchemaVersion != config.CurrentSchemaVersion {
+		t.Fatalf("expected schema to be bumped, got %d", cfg.SchemaVersion)
+	}
+	if cfg.UsedCodenames["0.1.0"].Codename != "Almond" || cfg.UsedCodenames["0.2.0"] != record {
+		t.Fatalf("unexpected records: %+v", cfg.UsedCodenames)
+	}
+}
+
+func TestUpdateRepoConfigRefusesRecordedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tagtastic.yaml")
+	original := "used_codenames:\n  0.2.0: Apricot\n"
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	err := updateRepoConfig(Options{ConfigPath: path}.withDefaults(), "0.2.0", config.Record{Codename: "Bittern"}, false)
+	if !errors.Is(err, config.ErrRecorded) {
+		t.Fatalf("expected already recorded error, got %v", err)
+	}
+	if payload, _ := os.ReadFile(path); string(payload) != original {
+		t.Fatalf("expected the record to be kept, got:\n%s", payload)
+	}
+
+	dir := filepath.Dir(path)
+	writeFile(t, filepath.Join(dir, "VERSION"), "0.1.0\n")
+	if _, err := Prepare(Options{Dir: dir, Version: "0.2.0", Codename: "Bittern", ConfigPath: path}); !errors.Is(err, config.ErrRecorded) {
+		t.Fatalf("expected Prepare to refuse a recorded version, got %v", err)
+	}
+}
+
+func TestEnsureSemVerForward(t *testing.T) {
+	if err := ensureSemVerForward("0.1.1", "0.1.0"); err != nil {
+		t.Fatalf("expected forward version, got error: %v", err)
+	}
+	if err := ensureSemVerForward("0.1.0", "0.1.0"); err == nil {
+		t.Fatalf("expected error for non-forward version")
+	}
+}
+
+func TestBumpVersion(t *testing.T) {
+	version, err := bumpVersion("0.1.0-beta.2", "patch")
+	if err != nil {
+		t.Fatalf("bumpVersion failed: %v", err)
+	}
+	if version != "0.1.1" {
+		t.Fatalf("expected 0.1.1, got %q", version)
+	}
+}
+
+func TestResolvePreReleaseVersionAutoNum(t *testing.T) {
+	tags := []string{"v0.1.1-beta.1", "v0.1.1-beta.2", "v0.1.1-rc.1"}
+	version, err := resolvePreReleaseVersion("0.1.1", "beta", 0, tags)
+	if err != nil {
+		t.Fatalf("resolvePreReleaseVersion failed: %v", err)
+	}
+	if version != "0.1.1-beta.3" {
+		t.Fatalf("expected 0.1.1-beta.3, got %q", version)
+	}
+}
+
+func TestResolvePreReleaseVersionManualNum(t *testing.T) {
+	version, err := resolvePreReleaseVersion("0.1.1", "rc", 5, nil)
+	if err != nil {
+		t.Fatalf("resolvePreReleaseVersion failed: %v", err)
+	}
+	if version != "0.1.1-rc.5" {
+		t.Fatalf("expected 0.1.1-rc.5, got %q", version)
+	}
+}
+
+func TestResolvePreReleaseVersionInvalidLabel(t *testing.T) {
+	if _, err := resolvePreReleaseVersion("0.1.1", "preview", 0, nil); err == nil {
+		t.Fatalf("expected error for invalid prerelease label")
+	}
+}
+
+func TestPrepare_Usage(t *testing.T) {
+	dir := t.TempDir()
+	for _, opts := range []Options{
+		{Dir: dir},
+		{Dir: dir, Version: "1.0.0", Bump: "patch"},
+		{Dir: dir, Bump: "patch"},
+		{Dir: dir, Version: "1.0.0", PreNum: 2},
+		{Dir: dir, Version: "1.0.0", Codename: "Almond", Date: "01/02/2026"},
+	} {
+		_, err := Prepare(opts)
+		if !errors.Is(err, ErrUsage) {
+			t.Fatalf("Prepare(%+v) = %v, want a usage error", opts, err)
+		}
+	}
+}
+
+func TestPrepareAndApply(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "CHANGELOG.md"), "# Changelog\n\n## [Unreleased]\n\n### Added\n- Tags.\n\n## [0.1.0] – \"Almond\" – 2026-01-01\n\n### Added\n- First.\n")
+	writeFile(t, filepath.Join(dir, ".tagtastic.yaml"), "used_codenames:\n  0.0.1: Antique Brass\n")
+	initRepo(t, dir, []string{"remote", "add", "origin", "git@gitlab.com:example/tags.git"})
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	opts := Options{
+		Dir:        dir,
+		Themes:     themes(t),
+		Bump:       "minor",
+		Pre:        "rc",
+		ConfigPath: filepath.Join(dir, ".tagtastic.yaml"),
+		Commit:     true,
+		Now:        func() time.Time { return now },
+		GitInfo:    func(string) git.Info { return git.Info{Commit: "abc123"} },
+	}
+	plan, err := Prepare(opts)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	want := Plan{
+		Version:  "0.2.0-rc.1",
+		Codename: "Apricot",
+		Theme:    DefaultTheme,
+		Date:     "2026-03-01",
+		Links:    Links{URL: "https://gitlab.com/example/tags", Compare: hostTemplates["gitlab"].compare, Release: hostTemplates["gitlab"].release},
+	}
+	if plan != want {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	if err := Apply(opts, plan); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	changelog, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("read changelog: %v", err)
+	}
+	if !strings.Contains(string(changelog), "## [0.2.0-rc.1] – \"Apricot\" – 2026-03-01\n\n### Added\n- Tags.") {
+		t.Fatalf("unexpected changelog:\n%s", changelog)
+	}
+	if !strings.HasSuffix(string(changelog), "[Unreleased]: https://gitlab.com/example/tags/-/compare/v0.2.0-rc.1...HEAD\n"+
+		"[0.2.0-rc.1]: https://gitlab.com/example/tags/-/compare/v0.1.0...v0.2.0-rc.1\n"+
+		"[0.1.0]: https://gitlab.com/example/tags/-/releases/v0.1.0\n") {
+		t.Fatalf("unexpected changelog links:\n%s", changelog)
+	}
+	cfg, err := config.Load(opts.ConfigPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if record := cfg.UsedCodenames["0.2.0-rc.1"]; record.Codename != "Apricot" || record.Commit != "abc123" {
+		t.Fatalf("unexpected record: %+v", record)
+	}
+	tags, err := git.Tags(dir)
+	if err != nil {
+		t.Fatalf("list tags: %v", err)
+	}
+	if len(tags) != 2 || tags[1].Name != "v0.2.0-rc.1" || tags[1].Subject != "v0.2.0-rc.1 – Apricot" {
+		t.Fatalf("unexpected tags: %+v", tags)
+	}
+}
+
+func TestPrepare_PicksFromTheme(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".tagtastic.yaml")
+	writeFile(t, configPath, "used_codenames:\n  0.1.0: Albatross\n")
+	opts := Options{Dir: dir, Version: "0.2.0", Themes: themes(t), Theme: "birds", ConfigPath: configPath}
+
+	plan, err := Prepare(opts)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if plan.Theme != "birds" || plan.Codename != "Blue Heron" || plan.Seed != 0 {
+		t.Fatalf("expected the first unused bird in theme order, got %+v", plan)
+	}
+
+	opts.Seed = 42
+	plan, err = Prepare(opts)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	again, err := Prepare(opts)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if plan.Seed != 42 || plan.Codename == "Albatross" || again != plan {
+		t.Fatalf("expected a repeatable seeded pick, got %+v and %+v", plan, again)
+	}
+
+	writeFile(t, configPath, "policy:\n  themes:\n    - allow: [cities]\n")
+	if _, err := Prepare(opts); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected a policy violation, got %v", err)
+	}
+	opts.Theme = "planets"
+	if _, err := Prepare(opts); !errors.Is(err, data.ErrThemeNotFound) {
+		t.Fatalf("expected an unknown theme, got %v", err)
+	}
+}
+
+func TestPrepareAndApply_PromotesPending(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".tagtastic.yaml")
+	writeFile(t, filepath.Join(dir, "CHANGELOG.md"), "# Changelog\n\n## [Unreleased]\n\n### Added\n- Birds.\n\n## [0.1.0] – \"Albatross\" – 2026-01-01\n")
+	writeFile(t, configPath, "default_theme: birds\n")
+	initRepo(t, dir)
+
+	doc, err := config.ReadDocument(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	pending := config.Record{Codename: "Cardinal", Theme: "birds", Seed: 9, Notes: "picked early"}
+	if err := doc.SetRecord(config.UnreleasedKey, pending); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	writeFile(t, configPath, string(doc.Bytes()))
+
+	opts := Options{Dir: dir, Themes: themes(t), Bump: "minor", ConfigPath: configPath}
+	plan, err := Prepare(opts)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if plan.Codename != "Cardinal" || plan.Theme != "birds" || plan.Seed != 9 || !plan.Promote {
+		t.Fatalf("expected the pending codename, got %+v", plan)
+	}
+	if err := Apply(opts, plan); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if _, ok := cfg.UsedCodenames[config.UnreleasedKey]; ok {
+		t.Fatalf("expected the unreleased record to be promoted, got %+v", cfg.UsedCodenames)
+	}
+	if record := cfg.UsedCodenames["0.2.0"]; record.Codename != "Cardinal" || record.Notes != "picked early" {
+		t.Fatalf("unexpected record: %+v", record)
+	}
+}
+
+func initRepo(t *testing.T, dir string, extra ...[]string) {
+	t.Helper()
+	for _, args := range append([][]string{
+		{"init", "-q"},
+		{"config", "user.email", "dev@example.com"},
+		{"config", "user.name", "Dev"},
+		{"add", "."},
+		{"commit", "-q", "-m", "initial"},
+		{"tag", "-a", "v0.1.0", "-m", "v0.1.0"},
+	}, extra...) {
+		if output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+}
+
+func themes(t *testing.T) data.ThemeRepository {
+	t.Helper()
+	repo, err := data.NewEmbeddedThemeRepository()
+	if err != nil {
+		t.Fatalf("load themes: %v", err)
+	}
+	return repo
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package release
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type semVer struct {
+	major    int
+	minor    int
+	patch    int
+	pre      string
+	preLabel string
+	preNum   int
+	hasPre   bool
+}
+
+var semVerPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?$`)
+
+func parseSemVer(input string) (semVer, error) {
+	match := semVerPattern.FindStringSubmatch(strings.TrimSpace(input))
+	if match == nil {
+		return semVer{}, fmt.Errorf("invalid SemVer: %s", input)
+	}
+
+	major, err := strconv.Atoi(match[1])
+	if err != nil {
+		return semVer{}, err
+	}
+	minor, err := strconv.Atoi(match[2])
+	if err != nil {
+		return semVer{}, err
+	}
+	patch, err := strconv.Atoi(match[3])
+	if err != nil {
+		return semVer{}, err
+	}
+
+	version := semVer{major: major, minor: minor, patch: patch}
+	if match[4] != "" {
+		version.hasPre = true
+		version.pre = match[4]
+		label, num := splitPreRelease(match[4])
+		version.preLabel = label
+		version.preNum = num
+	}
+
+	return version, nil
+}
+
+func splitPreRelease(value string) (string, int) {
+	parts := strings.Split(value, ".")
+	if len(parts) == 1 {
+		return value, 0
+	}
+	last := parts[len(parts)-1]
+	num, err := strconv.Atoi(last)
+	if err != nil {
+		return value, 0
+	}
+	label := strings.Join(parts[:len(parts)-1], ".")
+	if label == "" {
+		label = value
+	}
+	return label, num
+}
+
+func compareSemVer(a, b semVer) int {
+	if a.major != b.major {
+		return compareInt(a.major, b.major)
+	}
+	if a.minor != b.minor {
+		return compareInt(a.minor, b.minor)
+	}
+	if a.patch != b.patch {
+		return compareInt(a.patch, b.patch)
+	}
+	if !a.hasPre && !b.hasPre {
+		return 0
+	}
+	if !a.hasPre {
+		return 1
+	}
+	if !b.hasPre {
+		return -1
+	}
+
+	rankA := preReleaseRank(a.preLabel)
+	rankB := preReleaseRank(b.preLabel)
+	if rankA != rankB {
+		return compareInt(rankA, rankB)
+	}
+
+	if a.preLabel != b.preLabel {
+		if a.preLabel < b.preLabel {
+			return -1
+		}
+		if a.preLabel > b.preLabel {
+			return 1
+		}
+	}
+
+	if a.preNum != b.preNum {
+		return compareInt(a.preNum, b.preNum)
+	}
+	return 0
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func preReleaseRank(label string) int {
+	switch strings.ToLower(label) {
+	case "alpha":
+		return 0
+	case "beta":
+		return 1
+	case "rc":
+		return 2
+	default:
+		return 3
+	}
+}
+
+func formatSemVer(version semVer) string {
+	base := fmt.Sprintf("%d.%d.%d", version.major, version.minor, version.patch)
+	if version.hasPre && version.pre != "" {
+		return base + "-" + version.pre
+	}
+	return base
+}
+
+func ensureSemVerForward(version, latest string) error {
+	if strings.TrimSpace(version) == "" {
+		return errors.New("version is required")
+	}
+	parsed, err := parseSemVer(version)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(latest) == "" {
+		return nil
+	}
+	parsedLatest, err := parseSemVer(latest)
+	if err != nil {
+		return fmt.Errorf("invalid latest version %s: %w", latest, err)
+	}
+	if compareSemVer(parsed, parsedLatest) <= 0 {
+		return fmt.Errorf("version must be greater than %s", formatSemVer(parsedLatest))
+	}
+	return nil
+}
+
+func bumpVersion(base, bump string) (string, error) {
+	parsed, err := parseSemVer(base)
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(bump) {
+	case "major":
+		parsed.major++
+		parsed.minor = 0
+		parsed.patch = 0
+	case "minor":
+		parsed.minor++
+		parsed.patch = 0
+	case "patch":
+		parsed.patch++
+	default:
+		return "", fmt.Errorf("invalid bump value: %s (expected major, minor, or patch)", bump)
+	}
+	parsed.hasPre = false
+	parsed.pre = ""
+	parsed.preLabel = ""
+	parsed.preNum = 0
+	return formatSemVer(parsed), nil
+}
+
+func resolvePreReleaseVersion(baseVersion, label string, num int, tags []string) (string, error) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if err := validatePreLabel(label); err != nil {
+		return "", err
+	}
+
+	base, err := parseSemVer(baseVersion)
+	if err != nil {
+		return "", err
+	}
+	if base.hasPre {
+		return "", errors.New("version already includes prerelease; omit --pre")
+	}
+
+	if num < 0 {
+		return "", errors.New("pre-release number must be positive")
+	}
+	if num == 0 {
+		next, err := nextPreNumFromTags(baseVersion, label, tags)
+		if err != nil {
+			return "", err
+		}
+		num = next
+	}
+	if num < 1 {
+		return "", errors.New("pre-release number must be at least 1")
+	}
+
+	version := fmt.Sprintf("%d.%d.%d-%s.%d", base.major, base.minor, base.patch, label, num)
+	return version, nil
+}
+
+func validatePreLabel(label string) error {
+	switch strings.ToLower(strings.TrimSpace(label)) {
+	case "alpha", "beta", "rc":
+		return nil
+	default:
+		return fmt.Errorf("invalid prerelease label: %s", label)
+	}
+}
+
+func nextPreNumFromTags(baseVersion, label string, tags []string) (int, error) {
+	base, err := parseSemVer(baseVersion)
+	if err != nil {
+		return 0, err
+	}
+	if base.hasPre {
+		return 0, errors.New("base version must not include prerelease")
+	}
+
+	label = strings.ToLower(strings.TrimSpace(label))
+	max := 0
+	for _, tag := range tags {
+		parsed, err := parseSemVer(strings.TrimSpace(tag))
+		if err != nil || !parsed.hasPre {
+			continue
+		}
+		if parsed.major != base.major || parsed.minor != base.minor || parsed.patch != base.patch {
+			continue
+		}
+		if strings.ToLower(parsed.preLabel) != label {
+			continue
+		}
+		if parsed.preNum > max {
+			max = parsed.preNum
+		}
+	}
+	return max + 1, nil
+}
+
+// latestTagVersion returns the highest SemVer among tags, or "".
+func latestTagVersion(tags []string) string {
+	var latest semVer
+	found := false
+	for _, line := range tags {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parsed, err := parseSemVer(strings.TrimSpace(line))
+		if err != nil {
+			continue
+		}
+		if !found || compareSemVer(parsed, latest) > 0 {
+			latest = parsed
+			found = true
+		}
+	}
+
+	if !found {
+		return ""
+	}
+	return formatSemVer(latest)
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package selection
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"regexp"
+	"strings"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+// ErrPoolExhausted is returned when every item in a theme has been filtered out.
+var ErrPoolExhausted = errors.New("no available codenames after exclusions")
+
+// Seed sources reported in an Explanation.
+const (
+	SeedFromFlag = "flag"
+	SeedFromTime = "time"
+)
+
+// Filter removes items from the selection pool. Exclude reports whether an
+// item should be dropped and, if so, why.
+type Filter interface {
+	Name() string
+	Exclude(item data.CodeName) (string, bool)
+}
+
+// Options control how a codename is drawn from a theme.
+type Options struct {
+	Seed       int64
+	SeedSource string
+	Filters    []Filter
+	// Logger receives debug diagnostics for each stage; nil discards them.
+	Logger *slog.Logger
+}
+
+// Stage records the pool size before and after a single filter.
+type Stage struct {
+	Name   string `json:"name"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// Exclusion records an item removed from the pool and the reason.
+type Exclusion struct {
+	Name   string `json:"name"`
+	Stage  string `json:"stage"`
+	Reason string `json:"reason"`
+}
+
+// Explanation describes how a codename was chosen.
+type Explanation struct {
+	Theme      string      `json:"theme"`
+	PoolSize   int         `json:"pool_size"`
+	Stages     []Stage     `json:"stages"`
+	Excluded   []Exclusion `json:"excluded"`
+	Seed       int64       `json:"seed"`
+	SeedSource string      `json:"seed_source"`
+	Index      int         `json:"index"`
+	Selected   string      `json:"selected"`
+}
+
+// Result is the selected item together with its explanation.
+type Result struct {
+	Item        data.CodeName
+	Explanation Explanation
+}
+
+// Select applies the filters in order and draws one item using the seed.
+func Select(theme *data.Theme, opts Options) (Result, error) {
+	if theme == nil {
+		return Result{}, fmt.Errorf("theme is required")
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	explain := Explanation{
+		Theme:      theme.ID,
+		PoolSize:   len(theme.Items),
+		Stages:     []Stage{},
+		Excluded:   []Exclusion{},
+		Seed:       opts.Seed,
+		SeedSource: opts.SeedSource,
+		Index:      -1,
+	}
+
+	pool := append([]data.CodeName(nil), theme.Items...)
+	for _, filter := range opts.Filters {
+		if filter == nil {
+			continue
+		}
+		stage := Stage{Name: filter.Name(), Before: len(pool)}
+		kept := make([]data.CodeName, 0, len(pool))
+		for _, item := range pool {
+			if reason, excluded := filter.Exclude(item); excluded {
+				explain.Excluded = append(explain.Excluded, Exclusion{Name: item.Name, Stage: stage.Name, Reason: reason})
+				continue
+			}
+			kept = append(kept, item)
+		}
+		pool = kept
+		stage.After = len(pool)
+		explain.Stages = append(explain.Stages, stage)
+		logger.Debug("selection stage", "filter", stage.Name, "before", stage.Before, "after", stage.After)
+	}
+
+	if len(pool) == 0 {
+		return Result{Explanation: explain}, ErrPoolExhausted
+	}
+
+	// #nosec G404 - math/rand is sufficient for non-cryptographic codename selection
+	picker := rand.New(rand.NewSource(opts.Seed))
+	explain.Index = picker.Intn(len(pool))
+	explain.Selected = pool[explain.Index].Name
+	logger.Debug("selected codename", "name", explain.Selected, "pool", len(pool), "seed", opts.Seed, "seed_source", opts.SeedSource, "index", explain.Index)
+
+	return Result{Item: pool[explain.Index], Explanation: explain}, nil
+}
+
+type excludeFilter struct {
+	deny map[string]string
+}
+
+// ExcludeNames drops items whose name or any alias matches one of the given
+// names after normalization.
+func ExcludeNames(names []string) Filter {
+	deny := make(map[string]string, len(names))
+	for _, raw := range names {
+		key := data.NormalizeName(raw)
+		if key != "" {
+			deny[key] = raw
+		}
+	}
+	return excludeFilter{deny: deny}
+}
+
+func (excludeFilter) Name() string {
+	return "exclude"
+}
+
+func (f excludeFilter) Exclude(item data.CodeName) (string, bool) {
+	candidates := append([]string{item.Name}, item.Aliases...)
+	for _, candidate := range candidates {
+		if raw, ok := f.deny[data.NormalizeName(candidate)]; ok {
+			return fmt.Sprintf("matched --exclude %q", raw), true
+		}
+	}
+	return "", false
+}
+
+// Condition is one --where query against an item attribute: key=value
+// matches the value case-insensitively and key~regex matches a regular
+// expression.
+type Condition struct {
+	Key     string
+	Op      byte
+	Value   string
+	pattern *regexp.Regexp
+}
+
+// ParseCondition parses a key=value or key~regex query.
+func ParseCondition(expr string) (Condition, error) {
+	index := strings.IndexAny(expr, "=~")
+	if index <= 0 {
+		return Condition{}, fmt.Errorf("invalid --where %q: expected key=value or key~regex", expr)
+	}
+	cond := Condition{
+		Key:   strings.ToLower(strings.TrimSpace(expr[:index])),
+		Op:    expr[index],
+		Value: expr[index+1:],
+	}
+	if cond.Key == "" {
+		return Condition{}, fmt.Errorf("invalid --where %q: missing attribute name", expr)
+	}
+	if cond.Op == '~' {
+		pattern, err := regexp.Compile(cond.Value)
+		if err != nil {
+			return Condition{}, fmt.Errorf("invalid --where %q: %w", expr, err)
+		}
+		cond.pattern = pattern
+	}
+	return cond, nil
+}
+
+// Match reports whether item has the attribute and it satisfies the
+// condition.
+func (c Condition) Match(item data.CodeName) bool {
+	value, ok := item.Attributes[c.Key]
+	if !ok {
+		return false
+	}
+	if c.pattern != nil {
+		return c.pattern.MatchString(value)
+	}
+	return strings.EqualFold(value, c.Value)
+}
+
+func (c Condition) String() string {
+	return c.Key + string(c.Op) + c.Value
+}
+
+type whereFilter struct {
+	conditions []Condition
+}
+
+// Where keeps items that match every condition.
+func Where(conditions []Condition) Filter {
+	return whereFilter{conditions: conditions}
+}
+
+func (whereFilter) Name() string {
+	return "where"
+}
+
+func (f whereFilter) Exclude(item data.CodeName) (string, bool) {
+	for _, cond := range f.conditions {
+		if !cond.Match(item) {
+			if _, ok := item.Attributes[cond.Key]; !ok {
+				return fmt.Sprintf("no %q attribute for --where %s", cond.Key, cond), true
+			}
+			return fmt.Sprintf("did not match --where %s", cond), true
+		}
+	}
+	return "", false
+}
+
+// Apply returns the items that pass every filter, in order.
+func Apply(items []data.CodeName, filters ...Filter) []data.CodeName {
+	kept := make([]data.CodeName, 0, len(items))
+	for _, item := range items {
+		excluded := false
+		for _, filter := range filters {
+			if _, excluded = filter.Exclude(item); excluded {
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package selection
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/infravillage/tagtastic/internal/data"
+)
+
+func birdsTheme(t *testing.T) *data.Theme {
+	t.Helper()
+
+	repo, err := data.NewEmbeddedThemeRepository()
+	if err != nil {
+		t.Fatalf("load themes: %v", err)
+	}
+	theme, err := repo.GetThemeByName("birds")
+	if err != nil {
+		t.Fatalf("get theme: %v", err)
+	}
+	return theme
+}
+
+func TestSelect_Deterministic(t *testing.T) {
+	theme := birdsTheme(t)
+
+	first, err := Select(theme, Options{Seed: 42, SeedSource: SeedFromFlag})
+	if err != nil {
+		t.Fatalf("select failed: %v", err)
+	}
+	second, err := Select(theme, Options{Seed: 42, SeedSource: SeedFromFlag})
+	if err != nil {
+		t.Fatalf("select failed: %v", err)
+	}
+	if first.Item.Name != second.Item.Name {
+		t.Fatalf("expected same seed to select same item, got %q and %q", first.Item.Name, second.Item.Name)
+	}
+}
+
+func TestSelect_ExplainsExclusions(t *testing.T) {
+	theme := birdsTheme(t)
+
+	result, err := Select(theme, Options{
+		Seed:       1,
+		SeedSource: SeedFromFlag,
+		Filters:    []Filter{ExcludeNames([]string{"heron", "Eagle"})},
+	})
+	if err != nil {
+		t.Fatalf("select failed: %v", err)
+	}
+
+	explain := result.Explanation
+	if explain.Theme != "birds" || explain.PoolSize != 5 {
+		t.Fatalf("unexpected theme or pool size: %+v", explain)
+	}
+	if len(explain.Stages) != 1 || explain.Stages[0].Before != 5 || explain.Stages[0].After != 3 {
+		t.Fatalf("unexpected stages: %+v", explain.Stages)
+	}
+	if len(explain.Excluded) != 2 || explain.Excluded[0].Name != "Blue Heron" {
+		t.Fatalf("unexpected exclusions: %+v", explain.Excluded)
+	}
+	if explain.Selected != result.Item.Name || explain.Index < 0 {
+		t.Fatalf("expected selected item to be explained, got %+v", explain)
+	}
+}
+
+func TestSelect_PoolExhausted(t *testing.T) {
+	theme := birdsTheme(t)
+
+	result, err := Select(theme, Options{
+		Seed:    1,
+		Filters: []Filter{ExcludeNames([]string{"albatross", "heron", "crane", "dove", "eagle"})},
+	})
+	if !errors.Is(err, ErrPoolExhausted) {
+		t.Fatalf("expected ErrPoolExhausted, got %v", err)
+	}
+	if len(result.Explanation.Excluded) != 5 || result.Explanation.Index != -1 {
+		t.Fatalf("expected explanation for exhausted pool, got %+v", result.Explanation)
+	}
+}
+
+func TestWhere(t *testing.T) {
+	items := []data.CodeName{
+		{Name: "Kyoto", Attributes: data.Attributes{data.AttrCountry: "Japan", data.AttrContinent: "Asia"}},
+		{Name: "Lisbon", Attributes: data.Attributes{data.AttrCountry: "Portugal", data.AttrContinent: "Europe"}},
+		{Name: "Oslo", Attributes: data.Attributes{data.AttrCountry: "Norway", data.AttrContinent: "Europe"}},
+		{Name: "Plain"},
+	}
+
+	cases := map[string][]string{
+		"continent=europe":         {"Lisbon", "Oslo"},
+		"Country~^(Japan|Norway)$": {"Kyoto", "Oslo"},
+		"country~a":                {"Kyoto", "Lisbon", "Oslo"},
+		"country=Spain":            nil,
+	}
+
+	for expr, want := range cases {
+		cond, err := ParseCondition(expr)
+		if err != nil {
+			t.Fatalf("parse %q: %v", expr, err)
+		}
+		var got []string
+		for _, item := range Apply(items, Where([]Condition{cond})) {
+			got = append(got, item.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Fatalf("%s: expected %v, got %v", expr, want, got)
+		}
+	}
+
+	europe, _ := ParseCondition("continent=Europe")
+	norway, _ := ParseCondition("country=norway")
+	kept := Apply(items, Where([]Condition{europe, norway}))
+	if len(kept) != 1 || kept[0].Name != "Oslo" {
+		t.Fatalf("expected conditions to combine, got %v", kept)
+	}
+
+	if reason, excluded := Where([]Condition{europe}).Exclude(items[3]); !excluded || !strings.Contains(reason, `no "continent" attribute`) {
+		t.Fatalf("unexpected reason %q", reason)
+	}
+
+	for _, expr := range []string{"country", "=Japan", "country~("} {
+		if _, err := ParseCondition(expr); err == nil {
+			t.Fatalf("expected %q to be rejected", expr)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+// Package slug renders codenames as identifiers for specific targets, such
+// as Kubernetes labels, environment variables or Python versions, and checks
+// that the result is legal for the target.
+package slug
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalid is returned when a codename cannot be rendered as a legal slug
+// for the chosen style.
+var ErrInvalid = errors.New("invalid slug")
+
+// Style is a slug convention for one kind of target.
+type Style string
+
+const (
+	// Kebab is lowercase words joined by hyphens: blue-heron. It is the
+	// default and suits Docker tags, URLs and file names.
+	Kebab Style = "kebab"
+	// Snake is lowercase words joined by underscores: blue_heron.
+	Snake Style = "snake"
+	// Camel is camelCase, a valid Go or JavaScript identifier: blueHeron.
+	Camel Style = "camel"
+	// ScreamingSnake is uppercase words joined by underscores, a valid
+	// environment variable name: BLUE_HERON.
+	ScreamingSnake Style = "screaming-snake"
+	// DNS1123 is an RFC 1123 label as used for Kubernetes names and labels:
+	// at most 63 lowercase alphanumerics or hyphens.
+	DNS1123 Style = "dns1123"
+	// PEP440 is a PEP 440 local version label: lowercase alphanumerics
+	// separated by dots, as in 1.2.0+blue.heron.
+	PEP440 Style = "pep440"
+)
+
+// rule describes how a style joins words and what a legal slug looks like.
+type rule struct {
+	separator string
+	maxLength int
+	pattern   *regexp.Regexp
+}
+
+var rules = map[Style]rule{
+	Kebab:          {separator: "-", maxLength: 128, pattern: regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)},
+	Snake:          {separator: "_", pattern: regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)},
+	Camel:          {pattern: regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)},
+	ScreamingSnake: {separator: "_", pattern: regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)},
+	DNS1123:        {separator: "-", maxLength: 63, pattern: regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)},
+	PEP440:         {separator: ".", pattern: regexp.MustCompile(`^[a-z0-9]+(\.[a-z0-9]+)*$`)},
+}
+
+// Styles returns the supported style names in display order.
+func Styles() []string {
+	return []string{string(Kebab), string(Snake), string(Camel), string(ScreamingSnake), string(DNS1123), string(PEP440)}
+}
+
+// ParseStyle returns the style named by value; empty selects Kebab.
+func ParseStyle(value string) (Style, error) {
+	style := Style(strings.ToLower(strings.TrimSpace(value)))
+	if style == "" {
+		return Kebab, nil
+	}
+	if _, ok := rules[style]; !ok {
+		return "", fmt.Errorf("unknown slug style %q (supported: %s)", value, strings.Join(Styles(), ", "))
+	}
+	return style, nil
+}
+
+// Options select the style and an optional maximum length. MaxLength may
+// only tighten the style's own limit.
+type Options struct {
+	Style     Style
+	MaxLength int
+}
+
+// limit returns the effective maximum length, or 0 for none.
+func (o Options) limit() int {
+	limit := rules[o.style()].maxLength
+	if o.MaxLength > 0 && (limit == 0 || o.MaxLength < limit) {
+		return o.MaxLength
+	}
+	return limit
+}
+
+func (o Options) style() Style {
+	if o.Style == "" {
+		return Kebab
+	}
+	return o.Style
+}
+
+// Words splits text into lowercase ASCII words at every character that is
+// not a letter or digit.
+func Words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+}
+
+// Make renders text in the chosen style, shortened to the length limit at a
+// word boundary where possible, and validates the result.
+func Make(text string, opts Options) (string, error) {
+	style := opts.style()
+	r, ok := rules[style]
+	if !ok {
+		return "", fmt.Errorf("unknown slug style %q", style)
+	}
+
+	words := Words(text)
+	var value string
+	switch style {
+	case Camel:
+		for i, word := range words {
+			if i > 0 {
+				word = strings.ToUpper(word[:1]) + word[1:]
+			}
+			value += word
+		}
+	case ScreamingSnake:
+		value = strings.ToUpper(strings.Join(words, r.separator))
+	default:
+		value = strings.Join(words, r.separator)
+	}
+
+	if limit := opts.limit(); limit > 0 && len(value) > limit {
+		boundary := r.separator != "" && strings.HasPrefix(value[limit:], r.separator)
+		value = value[:limit]
+		if r.separator != "" && !boundary {
+			if cut := strings.LastIndex(value, r.separator); cut > 0 {
+				value = value[:cut]
+			}
+		}
+	}
+
+	if err := Validate(value, Options{Style: style, MaxLength: opts.MaxLength}); err != nil {
+		return "", fmt.Errorf("%w: %q cannot be written as a %s slug: %v", ErrInvalid, text, style, err)
+	}
+	return value, nil
+}
+
+// Validate reports why value is not a legal slug for the options, or nil.
+func Validate(value string, opts Options) error {
+	style := opts.style()
+	r, ok := rules[style]
+	if !ok {
+		return fmt.Errorf("unknown slug style %q", style)
+	}
+	if value == "" {
+		return errors.New("slug is empty")
+	}
+	if limit := opts.limit(); limit > 0 && len(value) > limit {
+		return fmt.Errorf("%q is longer than %d characters", value, limit)
+	}
+	if !r.pattern.MatchString(value) {
+		return fmt.Errorf("%q does not match %s", value, r.pattern)
+	}
+	return nil
+}
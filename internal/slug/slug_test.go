@@ -0,0 +1,91 @@
+// Copyright (c) 2026 InfraVillage
+// SPDX-License-Identifier: MIT
+//
+// This file is part of TAGtastic and is licensed under the MIT License.
+
+package slug
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	cases := []struct {
+		text string
+		opts Options
+		want string
+	}{
+		{"Blue Heron", Options{}, "blue-heron"},
+		{"blue-heron", Options{Style: Kebab}, "blue-heron"},
+		{"Blue Heron", Options{Style: Snake}, "blue_heron"},
+		{"Blue Heron", Options{Style: Camel}, "blueHeron"},
+		{"Blue Heron", Options{Style: ScreamingSnake}, "BLUE_HERON"},
+		{"Blue  Heron!", Options{Style: DNS1123}, "blue-heron"},
+		{"Blue Heron", Options{Style: PEP440}, "blue.heron"},
+		{"Blue Heron", Options{Style: DNS1123, MaxLength: 7}, "blue"},
+		{"Blue Heron Bird", Options{MaxLength: 10}, "blue-heron"},
+		{"Blue Heron Bird", Options{Style: Snake, MaxLength: 11}, "blue_heron"},
+		{"Kilimanjaro", Options{Style: DNS1123, MaxLength: 8}, "kilimanj"},
+		{strings.Repeat("a", 70), Options{Style: DNS1123}, strings.Repeat("a", 63)},
+	}
+	for _, tc := range cases {
+		got, err := Make(tc.text, tc.opts)
+		if err != nil {
+			t.Fatalf("Make(%q, %+v) failed: %v", tc.text, tc.opts, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Make(%q, %+v) = %q, want %q", tc.text, tc.opts, got, tc.want)
+		}
+	}
+}
+
+func TestMake_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		opts Options
+	}{
+		{"!!!", Options{}},
+		{"7 Wonders", Options{Style: Camel}},
+		{"7 Wonders", Options{Style: ScreamingSnake}},
+	} {
+		if _, err := Make(tc.text, tc.opts); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("Make(%q, %+v) = %v, want ErrInvalid", tc.text, tc.opts, err)
+		}
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	for _, name := range Styles() {
+		style, err := ParseStyle(strings.ToUpper(name))
+		if err != nil || string(style) != name {
+			t.Fatalf("ParseStyle(%q) = %q, %v", name, style, err)
+		}
+	}
+	if style, err := ParseStyle(""); err != nil || style != Kebab {
+		t.Fatalf("expected empty style to default to kebab, got %q, %v", style, err)
+	}
+	if _, err := ParseStyle("title"); err == nil || !strings.Contains(err.Error(), "dns1123") {
+		t.Fatalf("expected unknown style error listing styles, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate("blue-heron", Options{Style: DNS1123}); err != nil {
+		t.Fatalf("expected valid label: %v", err)
+	}
+	for _, tc := range []struct {
+		value string
+		opts  Options
+	}{
+		{"-blue", Options{Style: DNS1123}},
+		{"Blue", Options{Style: Kebab}},
+		{"blue.", Options{Style: PEP440}},
+		{"blue_heron", Options{Style: Snake, MaxLength: 4}},
+	} {
+		if err := Validate(tc.value, tc.opts); err == nil {
+			t.Fatalf("expected %q to be invalid for %+v", tc.value, tc.opts)
+		}
+	}
+}